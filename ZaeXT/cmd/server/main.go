@@ -4,6 +4,7 @@ import (
 	"ai-qa-backend/internal/adapter/volcengine"
 	"ai-qa-backend/internal/configs"
 	"ai-qa-backend/internal/handler"
+	"ai-qa-backend/internal/pkg/notifier"
 	"ai-qa-backend/internal/repository"
 	"ai-qa-backend/internal/repository/db"
 	"ai-qa-backend/internal/service"
@@ -31,9 +32,21 @@ func main() {
 
 	aiAdapter := volcengine.NewVolcengineAdapter()
 
-	services := service.NewService(repos, aiAdapter)
+	notificationSender, err := notifier.New(configs.Conf.Notifier.Driver, configs.Conf.Notifier.FilePath)
+	if err != nil {
+		log.Fatalf("Failed to initialize notifier: %v", err)
+	}
+
+	services := service.NewService(repos, aiAdapter, notificationSender)
+	if err := services.Admin.BootstrapAdmins(); err != nil {
+		log.Fatalf("Failed to bootstrap admin users: %v", err)
+	}
+	if err := services.Job.FailOrphanedJobs(); err != nil {
+		log.Fatalf("Failed to recover orphaned jobs: %v", err)
+	}
 
 	cronScheduler := tasks.StartCronJobs(services)
+	workerPool := tasks.StartWorkers(services)
 
 	router := handler.SetupRouter(services)
 
@@ -65,6 +78,14 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	workerCtx, workerCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer workerCancel()
+	if err := workerPool.Stop(workerCtx); err != nil {
+		log.Printf("Background task workers did not stop in time, unfinished tasks will be requeued: %v", err)
+	} else {
+		log.Println("Background task workers stopped.")
+	}
+
 	sqlDB, err := gormDB.DB()
 	if err == nil {
 		sqlDB.Close()
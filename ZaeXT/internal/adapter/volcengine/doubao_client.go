@@ -98,7 +98,12 @@ func (a *VolcengineAdapter) GetAvailableModelsForTier(userTier string) []Availab
 	return result
 }
 
-func (a *VolcengineAdapter) isValidModelForTier(modelID, userTier string) bool {
+func (a *VolcengineAdapter) IsValidTier(tier string) bool {
+	_, ok := a.tierLevels[tier]
+	return ok
+}
+
+func (a *VolcengineAdapter) IsValidModelForTier(modelID, userTier string) bool {
 	userLevel, ok := a.tierLevels[userTier]
 	if !ok {
 		userLevel = 0
@@ -124,7 +129,7 @@ func (a *VolcengineAdapter) ChatStream(req ChatRequest, userTier, modelID string
 		defer close(responseChan)
 		defer close(errChan)
 
-		if !a.isValidModelForTier(modelID, userTier) {
+		if !a.IsValidModelForTier(modelID, userTier) {
 			errChan <- errors.New("permission denied for the selected model")
 			return
 		}
@@ -10,12 +10,20 @@ import (
 var Conf *Config
 
 type Config struct {
-	Server     ServerConfig     `mapstructure:"server"`
-	Database   DatabaseConfig   `mapstructure:"database"`
-	JWT        JWTConfig        `mapstructure:"jwt"`
-	VolcEngine VolcEngineConfig `mapstructure:"volcengine"`
-	Log        LogConfig        `mapstructure:"log"`
-	RecycleBin RecycleBinConfig `mapstructure:"recycle_bin"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Database     DatabaseConfig     `mapstructure:"database"`
+	JWT          JWTConfig          `mapstructure:"jwt"`
+	VolcEngine   VolcEngineConfig   `mapstructure:"volcengine"`
+	Log          LogConfig          `mapstructure:"log"`
+	RecycleBin   RecycleBinConfig   `mapstructure:"recycle_bin"`
+	Temporary    TemporaryConfig    `mapstructure:"temporary_conversation"`
+	AutoClassify AutoClassifyConfig `mapstructure:"auto_classify"`
+	Tasks        TaskConfig         `mapstructure:"background_tasks"`
+	Jobs         JobConfig          `mapstructure:"jobs"`
+	Category     CategoryConfig     `mapstructure:"category"`
+	Admin        AdminConfig        `mapstructure:"admin"`
+	Password     PasswordConfig     `mapstructure:"password"`
+	Notifier     NotifierConfig     `mapstructure:"notifier"`
 }
 
 type ServerConfig struct {
@@ -34,8 +42,10 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret     string        `mapstructure:"secret"`
-	Expiration time.Duration `mapstructure:"expiration"`
+	Secret            string        `mapstructure:"secret"`
+	Expiration        time.Duration `mapstructure:"expiration"`
+	RefreshExpiration time.Duration `mapstructure:"refresh_expiration"`
+	AccessCacheTTL    time.Duration `mapstructure:"access_cache_ttl"`
 }
 
 type ModelInfo struct {
@@ -56,7 +66,60 @@ type LogConfig struct {
 }
 
 type RecycleBinConfig struct {
-	RetentionDays int `mapstructure:"retention_days"`
+	RetentionDays        int            `mapstructure:"retention_days"`
+	MinRetentionDays     int            `mapstructure:"min_retention_days"`
+	MaxRetentionDays     int            `mapstructure:"max_retention_days"`
+	TierMaxRetentionDays map[string]int `mapstructure:"tier_max_retention_days"`
+	PurgeBatchSize       int            `mapstructure:"purge_batch_size"`
+	PurgeBatchPause      time.Duration  `mapstructure:"purge_batch_pause"`
+}
+
+type TemporaryConfig struct {
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+}
+
+type AutoClassifyConfig struct {
+	Interval          time.Duration `mapstructure:"interval"`
+	MaxConcurrentJobs int           `mapstructure:"max_concurrent_jobs"`
+}
+
+type TaskConfig struct {
+	Workers       int           `mapstructure:"workers"`
+	PollInterval  time.Duration `mapstructure:"poll_interval"`
+	MaxAttempts   int           `mapstructure:"max_attempts"`
+	RetryBackoff  time.Duration `mapstructure:"retry_backoff"`
+	LockTimeout   time.Duration `mapstructure:"lock_timeout"`
+	Retention     time.Duration `mapstructure:"retention"`
+	AutoClassify  bool          `mapstructure:"auto_classify"`
+	Summarize     bool          `mapstructure:"summarize"`
+	ExtractMemory bool          `mapstructure:"extract_memory"`
+}
+
+type JobConfig struct {
+	HeartbeatTimeout time.Duration `mapstructure:"heartbeat_timeout"`
+}
+
+type CategoryConfig struct {
+	MaxDepth int `mapstructure:"max_depth"`
+}
+
+type AdminConfig struct {
+	BootstrapUsernames []string `mapstructure:"bootstrap_usernames"`
+}
+
+type PasswordConfig struct {
+	MinLength            int           `mapstructure:"min_length"`
+	RequireUpper         bool          `mapstructure:"require_upper"`
+	RequireLower         bool          `mapstructure:"require_lower"`
+	RequireDigit         bool          `mapstructure:"require_digit"`
+	RequireSymbol        bool          `mapstructure:"require_symbol"`
+	ResetTokenTTL        time.Duration `mapstructure:"reset_token_ttl"`
+	ResetRequestsPerHour int           `mapstructure:"reset_requests_per_hour"`
+}
+
+type NotifierConfig struct {
+	Driver   string `mapstructure:"driver"`
+	FilePath string `mapstructure:"file_path"`
 }
 
 func Init() error {
@@ -65,6 +128,30 @@ func Init() error {
 	viper.AddConfigPath("./configs/")
 	viper.AddConfigPath(".")
 
+	viper.SetDefault("jwt.expiration", "15m")
+	viper.SetDefault("jwt.refresh_expiration", "720h")
+	viper.SetDefault("jwt.access_cache_ttl", "30s")
+	viper.SetDefault("recycle_bin.min_retention_days", 1)
+	viper.SetDefault("recycle_bin.max_retention_days", 90)
+	viper.SetDefault("recycle_bin.purge_batch_size", 200)
+	viper.SetDefault("recycle_bin.purge_batch_pause", "200ms")
+	viper.SetDefault("password.min_length", 8)
+	viper.SetDefault("password.require_lower", true)
+	viper.SetDefault("password.require_digit", true)
+	viper.SetDefault("password.reset_token_ttl", "30m")
+	viper.SetDefault("password.reset_requests_per_hour", 5)
+	viper.SetDefault("notifier.driver", "log")
+	viper.SetDefault("temporary_conversation.idle_timeout", "2h")
+	viper.SetDefault("category.max_depth", 5)
+	viper.SetDefault("auto_classify.max_concurrent_jobs", 1)
+	viper.SetDefault("background_tasks.workers", 2)
+	viper.SetDefault("background_tasks.poll_interval", "2s")
+	viper.SetDefault("background_tasks.max_attempts", 5)
+	viper.SetDefault("background_tasks.retry_backoff", "30s")
+	viper.SetDefault("background_tasks.lock_timeout", "10m")
+	viper.SetDefault("background_tasks.retention", "168h")
+	viper.SetDefault("jobs.heartbeat_timeout", "2m")
+
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			return fmt.Errorf("config file not found: %w", err)
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/request"
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/pkg/pagination"
+	"ai-qa-backend/internal/repository"
+	"ai-qa-backend/internal/service"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminService service.AdminService
+}
+
+func NewAdminHandler(adminService service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var req request.AdminListUsers
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	users, nextCursor, err := h.adminService.ListUsers(adminActor(c), service.AdminUserListParams{
+		Search:   req.Query,
+		Tier:     req.Tier,
+		Role:     req.Role,
+		Disabled: req.Disabled,
+		Cursor:   req.Cursor,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Fail(c, e.InvalidParams, "无效的分页游标")
+		} else {
+			response.Fail(c, e.Error, "获取用户列表失败")
+		}
+		return
+	}
+
+	if nextCursor != "" {
+		c.Header("X-Next-Cursor", nextCursor)
+	}
+	userInfos := make([]*response.AdminUserInfo, len(users))
+	for i, user := range users {
+		userInfos[i] = transformAdminUserToDTO(user, nil)
+	}
+
+	response.Success(c, userInfos)
+}
+
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的用户ID")
+		return
+	}
+
+	user, usage, err := h.adminService.GetUser(adminActor(c), uint(userID))
+	if err != nil {
+		h.fail(c, err, "获取用户信息失败")
+		return
+	}
+
+	response.Success(c, transformAdminUserToDTO(user, usage))
+}
+
+func (h *AdminHandler) UpdateTier(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的用户ID")
+		return
+	}
+
+	var req request.AdminUpdateTier
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	user, err := h.adminService.UpdateTier(adminActor(c), uint(userID), req.Tier)
+	if err != nil {
+		h.fail(c, err, "更新用户等级失败")
+		return
+	}
+
+	response.Success(c, transformAdminUserToDTO(user, nil))
+}
+
+func (h *AdminHandler) UpdateStatus(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的用户ID")
+		return
+	}
+
+	var req request.AdminUpdateStatus
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	user, err := h.adminService.SetDisabled(adminActor(c), uint(userID), *req.Disabled)
+	if err != nil {
+		h.fail(c, err, "更新用户状态失败")
+		return
+	}
+
+	response.Success(c, transformAdminUserToDTO(user, nil))
+}
+
+func (h *AdminHandler) UpdatePasswordReset(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的用户ID")
+		return
+	}
+
+	var req request.AdminUpdatePasswordReset
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	user, err := h.adminService.SetPasswordResetRequired(adminActor(c), uint(userID), *req.Required)
+	if err != nil {
+		h.fail(c, err, "更新密码重置状态失败")
+		return
+	}
+
+	response.Success(c, transformAdminUserToDTO(user, nil))
+}
+
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	var req request.AdminListAuditLogs
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	logs, nextCursor, err := h.adminService.ListAuditLogs(service.AdminAuditLogListParams{
+		AdminID:      req.AdminID,
+		TargetUserID: req.TargetUserID,
+		Action:       req.Action,
+		Cursor:       req.Cursor,
+		Limit:        req.Limit,
+	})
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Fail(c, e.InvalidParams, "无效的分页游标")
+		} else {
+			response.Fail(c, e.Error, "获取审计日志失败")
+		}
+		return
+	}
+
+	if nextCursor != "" {
+		c.Header("X-Next-Cursor", nextCursor)
+	}
+	logInfos := make([]*response.AdminAuditLog, len(logs))
+	for i, entry := range logs {
+		logInfos[i] = &response.AdminAuditLog{
+			ID:           entry.ID,
+			AdminID:      entry.AdminID,
+			TargetUserID: entry.TargetUserID,
+			Action:       entry.Action,
+			IP:           entry.IP,
+			CreatedAt:    entry.CreatedAt,
+		}
+		if entry.Details != "" {
+			logInfos[i].Details = json.RawMessage(entry.Details)
+		}
+	}
+
+	response.Success(c, logInfos)
+}
+
+func (h *AdminHandler) fail(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrAdminUserNotFound):
+		response.Fail(c, e.NotFound, "用户不存在")
+	case errors.Is(err, service.ErrInvalidTier):
+		response.Fail(c, e.InvalidParams, "无效的会员等级")
+	case errors.Is(err, service.ErrCannotModifySelf):
+		response.Fail(c, e.InvalidParams, "不能禁用或重置自己的账号")
+	default:
+		response.Fail(c, e.Error, message)
+	}
+}
+
+func adminActor(c *gin.Context) service.AdminActor {
+	userID, _ := c.Get("userID")
+	return service.AdminActor{ID: userID.(uint), IP: c.ClientIP()}
+}
+
+func transformAdminUserToDTO(user *model.User, usage *repository.UserUsage) *response.AdminUserInfo {
+	info := &response.AdminUserInfo{
+		ID:                    user.ID,
+		Username:              user.Username,
+		Tier:                  user.Tier,
+		Role:                  user.Role,
+		Disabled:              user.DisabledAt != nil,
+		DisabledAt:            user.DisabledAt,
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             user.CreatedAt,
+	}
+	if usage != nil {
+		info.Usage = &response.UserUsage{
+			Conversations:         usage.Conversations,
+			ArchivedConversations: usage.ArchivedConversations,
+			TrashedConversations:  usage.TrashedConversations,
+			Categories:            usage.Categories,
+			Messages:              usage.Messages,
+			RecentMessages:        usage.RecentMessages,
+			LastActiveAt:          usage.LastActiveAt,
+			ActiveSessions:        usage.ActiveSessions,
+		}
+	}
+	return info
+}
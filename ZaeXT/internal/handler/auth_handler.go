@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/request"
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/service"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	authService service.AuthService
+}
+
+func NewAuthHandler(authService service.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req request.RefreshToken
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	tokens, err := h.authService.Refresh(req.RefreshToken, sessionMeta(c))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			response.Fail(c, e.Unauthorized, "refresh token无效或已过期，请重新登录")
+		} else {
+			response.Fail(c, e.Error, "刷新token失败")
+		}
+		return
+	}
+
+	response.Success(c, transformTokenPairToDTO(tokens))
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sessionID, _ := c.Get("sessionID")
+
+	if err := h.authService.Logout(userID.(uint), sessionID.(uint)); err != nil {
+		response.Fail(c, e.Error, "退出登录失败")
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	revoked, err := h.authService.LogoutAll(userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "退出所有设备失败")
+		return
+	}
+
+	response.Success(c, gin.H{"revoked_sessions": revoked})
+}
+
+func sessionMeta(c *gin.Context) service.SessionMeta {
+	return service.SessionMeta{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+func transformTokenPairToDTO(tokens *service.TokenPair) *response.TokenPair {
+	return &response.TokenPair{
+		Token:            tokens.AccessToken,
+		ExpiresAt:        tokens.AccessExpiresAt,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+
+		PasswordResetRequired: tokens.PasswordResetRequired,
+	}
+}
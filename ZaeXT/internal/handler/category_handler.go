@@ -3,10 +3,12 @@ package handler
 import (
 	"ai-qa-backend/internal/handler/request"
 	"ai-qa-backend/internal/handler/response"
-	"ai-qa-backend/internal/model"
 	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/repository"
 	"ai-qa-backend/internal/service"
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,7 +32,13 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 
 	category, err := h.categoryService.Create(userID.(uint), req.Name, req.ParentID)
 	if err != nil {
-		response.Fail(c, e.Error, "创建分类失败")
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else if errors.Is(err, service.ErrCategoryCycle) || errors.Is(err, service.ErrCategoryTooDeep) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, "创建分类失败")
+		}
 		return
 	}
 
@@ -38,6 +46,7 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 		ID:       category.ID,
 		Name:     category.Name,
 		ParentID: category.ParentID,
+		Position: category.Position,
 	}
 
 	response.Success(c, res)
@@ -46,17 +55,58 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 func (h *CategoryHandler) List(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
-	categories, err := h.categoryService.List(userID.(uint))
+	var req request.ListCategories
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	categories, err := h.categoryService.List(userID.(uint), req.MaxDepth, req.WithStats)
 	if err != nil {
 		response.Fail(c, e.Error, "获取分类列表失败")
 		return
 	}
 
 	responseCategories := transformCategoriesToDTO(categories)
+	if responseCategories == nil {
+		responseCategories = []*response.CategoryInfo{}
+	}
 
 	response.Success(c, responseCategories)
 }
 
+func (h *CategoryHandler) Activity(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.CategoryActivity
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	activity, err := h.categoryService.Activity(userID.(uint), req.Bucket, req.From, req.To)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimeRange) || errors.Is(err, repository.ErrInvalidActivityPeriod) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, "获取分类统计失败")
+		}
+		return
+	}
+
+	items := make([]*response.CategoryActivity, len(activity))
+	for i, item := range activity {
+		items[i] = &response.CategoryActivity{
+			CategoryID:    item.CategoryID,
+			Period:        item.Period,
+			Conversations: item.Conversations,
+			Messages:      item.Messages,
+		}
+	}
+
+	response.Success(c, items)
+}
+
 func (h *CategoryHandler) Update(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
@@ -72,14 +122,20 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	}
 
 	if err := h.categoryService.Update(uint(categoryID), userID.(uint), req.Name, req.ParentID); err != nil {
-		response.Fail(c, e.Error, err.Error())
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else if errors.Is(err, service.ErrCategoryCycle) || errors.Is(err, service.ErrCategoryTooDeep) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, err.Error())
+		}
 		return
 	}
 
 	response.Success(c, nil)
 }
 
-func (h *CategoryHandler) Delete(c *gin.Context) {
+func (h *CategoryHandler) UpdatePosition(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -87,26 +143,109 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.categoryService.Delete(uint(categoryID), userID.(uint)); err != nil {
-		response.Fail(c, e.Error, err.Error())
+	var req request.UpdateCategoryPosition
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.categoryService.SetPosition(uint(categoryID), userID.(uint), *req.Position); err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, "调整分类顺序失败")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *CategoryHandler) Reorder(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.ReorderCategories
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.categoryService.ReorderSiblings(userID.(uint), req.ParentID, req.IDs); err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else if errors.Is(err, service.ErrInvalidOrder) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, "调整分类顺序失败")
+		}
 		return
 	}
 
 	response.Success(c, nil)
 }
 
-func transformCategoriesToDTO(categories []*model.Category) []*response.CategoryInfo {
-	if categories == nil {
+func (h *CategoryHandler) Delete(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的分类ID")
+		return
+	}
+
+	var req request.DeleteCategory
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	result, err := h.categoryService.Delete(uint(categoryID), userID.(uint), req.Strategy, req.Preview)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else if errors.Is(err, service.ErrInvalidDeleteStrategy) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, &response.CategoryDeleteResult{
+		Strategy:      result.Strategy,
+		Preview:       req.Preview,
+		Categories:    result.Categories,
+		Conversations: result.Conversations,
+	})
+}
+
+func transformCategoriesToDTO(nodes []*service.CategoryNode) []*response.CategoryInfo {
+	if len(nodes) == 0 {
 		return nil
 	}
-	dtos := make([]*response.CategoryInfo, len(categories))
-	for i, category := range categories {
+	dtos := make([]*response.CategoryInfo, len(nodes))
+	for i, node := range nodes {
 		dtos[i] = &response.CategoryInfo{
-			ID:       category.ID,
-			Name:     category.Name,
-			ParentID: category.ParentID,
-			Children: transformCategoriesToDTO(category.Children),
+			ID:       node.ID,
+			Name:     node.Name,
+			ParentID: node.ParentID,
+			Position: node.Position,
+			Path:     node.Path,
+			Depth:    node.Depth,
+			Stats:    transformCategoryStatsToDTO(node.Stats),
+			Children: transformCategoriesToDTO(node.Children),
 		}
 	}
 	return dtos
 }
+
+func transformCategoryStatsToDTO(stats *service.CategoryStats) *response.CategoryStats {
+	if stats == nil {
+		return nil
+	}
+	return &response.CategoryStats{
+		DirectConversations: stats.DirectConversations,
+		TotalConversations:  stats.TotalConversations,
+		Messages:            stats.Messages,
+		LastActivityAt:      stats.LastActivityAt,
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"ai-qa-backend/internal/handler/response"
 	"ai-qa-backend/internal/model"
 	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/pkg/pagination"
+	"ai-qa-backend/internal/repository"
 	"ai-qa-backend/internal/service"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -31,12 +35,74 @@ func (h *ChatHandler) AutoClassify(c *gin.Context) {
 		return
 	}
 
-	if err := h.chatService.AutoClassify(uint(conv), userID.(uint)); err != nil {
+	result, err := h.chatService.AutoClassify(uint(conv), userID.(uint))
+	if err != nil {
 		response.Fail(c, e.Error, err.Error())
 		return
 	}
 
-	response.Success(c, nil)
+	response.Success(c, result)
+}
+
+func (h *ChatHandler) ConfirmProposedCategory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.ConfirmProposedCategory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	proposal := service.CategoryProposal{Name: strings.TrimSpace(req.Name), ParentID: req.ParentID}
+	if proposal.Name == "" {
+		response.Fail(c, e.InvalidParams, "分类名称不能为空")
+		return
+	}
+
+	category, err := h.chatService.ConfirmProposedCategory(uint(convID), userID.(uint), proposal)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else if strings.HasPrefix(err.Error(), "cannot") || errors.Is(err, service.ErrCategoryTooDeep) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, "创建分类失败")
+		}
+		return
+	}
+
+	response.Success(c, &response.CategoryInfo{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+	})
+}
+
+func (h *ChatHandler) StartAutoClassify(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.StartAutoClassify
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	job, err := h.chatService.StartAutoClassify(userID.(uint), req.ConversationIDs, req.DryRun)
+	if errors.Is(err, service.ErrTooManyJobs) {
+		response.Fail(c, e.TooManyRequests, "已有进行中的自动分类任务，请等待其完成后再试")
+		return
+	}
+	if err != nil {
+		response.Fail(c, e.Error, "创建自动分类任务失败")
+		return
+	}
+
+	response.Success(c, transformJobToDTO(job))
 }
 
 func (h *ChatHandler) UpdateConversationCategory(c *gin.Context) {
@@ -89,12 +155,38 @@ func (h *ChatHandler) CreateConversation(c *gin.Context) {
 func (h *ChatHandler) ListConversations(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
-	convs, err := h.chatService.ListConversations(userID.(uint))
+	var req request.ListConversations
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	convs, nextCursor, err := h.chatService.ListConversations(userID.(uint), service.ListConversationsParams{
+		CategoryID:         req.CategoryID,
+		IncludeDescendants: req.IncludeDescendants,
+		Uncategorized:      req.Uncategorized,
+		TagIDs:             req.TagIDs,
+		Search:             req.Query,
+		Archived:           req.Archived,
+		SortBy:             req.Sort,
+		Order:              req.Order,
+		Cursor:             req.Cursor,
+		Limit:              req.Limit,
+	})
 	if err != nil {
-		response.Fail(c, e.Error, "获取对话列表失败")
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			response.Fail(c, e.InvalidParams, "无效的分页游标")
+		} else if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, "获取对话列表失败")
+		}
 		return
 	}
 
+	if nextCursor != "" {
+		c.Header("X-Next-Cursor", nextCursor)
+	}
 	convInfos := h.transformConversationsToDTO(convs)
 
 	response.Success(c, convInfos)
@@ -138,6 +230,103 @@ func (h *ChatHandler) DeleteConversation(c *gin.Context) {
 	response.Success(c, nil)
 }
 
+func (h *ChatHandler) EndTemporaryConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	if err := h.chatService.EndTemporaryConversation(uint(convID), userID.(uint)); err != nil {
+		response.Fail(c, e.Error, err.Error())
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *ChatHandler) ForkConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.ForkConversation
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	conv, err := h.chatService.ForkConversation(uint(convID), userID.(uint), req.MessageID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMessageNotInConversation) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, "复制对话失败")
+		}
+		return
+	}
+
+	response.Success(c, h.transformConversationsToDTO([]*model.Conversation{conv})[0])
+}
+
+func (h *ChatHandler) PinConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.PinConversation
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.chatService.SetConversationPinned(uint(convID), userID.(uint), req.Pinned); err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.InvalidParams, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *ChatHandler) ArchiveConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.ArchiveConversation
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.chatService.SetConversationArchived(uint(convID), userID.(uint), req.Archived); err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.InvalidParams, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
 func (h *ChatHandler) ProcessMessage(c *gin.Context) {
 	conv, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -215,12 +404,18 @@ func (h *ChatHandler) transformConversationsToDTO(convs []*model.Conversation) [
 	convInfos := make([]response.ConversationInfo, len(convs))
 	for i, conv := range convs {
 		convInfos[i] = response.ConversationInfo{
-			ID:          conv.ID,
-			Title:       conv.Title,
-			IsTemporary: conv.IsTemporary,
-			CategoryID:  conv.CategoryID,
-			CreatedAt:   conv.CreatedAt,
-			UpdatedAt:   conv.UpdatedAt,
+			ID:             conv.ID,
+			Title:          conv.Title,
+			IsTemporary:    conv.IsTemporary,
+			CategoryID:     conv.CategoryID,
+			ModelID:        conv.ModelID,
+			EnableThinking: conv.EnableThinking,
+			Summary:        conv.Summary,
+			PinnedAt:       conv.PinnedAt,
+			ArchivedAt:     conv.ArchivedAt,
+			Tags:           transformTagsToDTO(conv.Tags),
+			CreatedAt:      conv.CreatedAt,
+			UpdatedAt:      conv.UpdatedAt,
 		}
 	}
 	return convInfos
@@ -252,12 +447,71 @@ func (h *ChatHandler) GetMessages(c *gin.Context) {
 	messageInfo := make([]response.MessageInfo, len(messages))
 	for i, msg := range messages {
 		messageInfo[i] = response.MessageInfo{
-			ID:        msg.ID,
-			Role:      msg.Role,
-			Content:   msg.Content,
-			CreatedAt: msg.CreatedAt,
+			ID:             msg.ID,
+			Role:           msg.Role,
+			Content:        msg.Content,
+			ModelID:        msg.ModelID,
+			EnableThinking: msg.EnableThinking,
+			CreatedAt:      msg.CreatedAt,
 		}
 	}
 
 	response.Success(c, messageInfo)
 }
+
+func (h *ChatHandler) BatchMoveConversations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.BatchMoveConversations
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	results, err := h.chatService.BatchMoveConversations(req.IDs, userID.(uint), req.CategoryID)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, "批量移动对话失败")
+		}
+		return
+	}
+
+	response.Success(c, transformBatchResultToDTO(results))
+}
+
+func (h *ChatHandler) BatchDeleteConversations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.BatchConversationIDs
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	results, err := h.chatService.BatchDeleteConversations(req.IDs, userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "批量删除对话失败")
+		return
+	}
+
+	response.Success(c, transformBatchResultToDTO(results))
+}
+
+func transformBatchResultToDTO(results []service.BatchItemResult) *response.BatchResult {
+	batch := &response.BatchResult{Results: make([]*response.BatchItemResult, len(results))}
+	for i, result := range results {
+		batch.Results[i] = &response.BatchItemResult{
+			ID:      result.ID,
+			Success: result.Success,
+			Error:   result.Error,
+		}
+		if result.Success {
+			batch.Succeeded++
+		} else {
+			batch.Failed++
+		}
+	}
+	return batch
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/request"
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/pkg/exporter"
+	"ai-qa-backend/internal/service"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportService service.ExportService
+}
+
+func NewExportHandler(exportService service.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+func (h *ExportHandler) Export(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.ExportConversation
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+	if req.Format == "" {
+		req.Format = exporter.FormatMarkdown
+	}
+
+	file, err := h.exportService.Export(uint(convID), userID.(uint), req.Format, req.IncludeReasoning)
+	if err != nil {
+		h.fail(c, userID.(uint), err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (h *ExportHandler) ExportArchive(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.ExportConversations
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+	if req.Format == "" {
+		req.Format = exporter.FormatMarkdown
+	}
+
+	writeArchive, err := h.exportService.ExportArchive(req.IDs, userID.(uint), req.Format, req.IncludeReasoning)
+	if err != nil {
+		h.fail(c, userID.(uint), err)
+		return
+	}
+
+	fileName := fmt.Sprintf("conversations-%s.zip", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	c.Status(http.StatusOK)
+
+	if err := writeArchive(c.Writer); err != nil {
+		log.Printf("ERROR: Failed to stream conversation archive for user %d: %v", userID.(uint), err)
+	}
+}
+
+func (h *ExportHandler) fail(c *gin.Context, userID uint, err error) {
+	switch {
+	case errors.Is(err, service.ErrConversationNotFound):
+		response.Fail(c, e.PermissionDenied, err.Error())
+	case errors.Is(err, exporter.ErrUnsupportedFormat):
+		response.Fail(c, e.InvalidParams, err.Error())
+	default:
+		log.Printf("ERROR: Failed to export conversations for user %d: %v", userID, err)
+		response.Fail(c, e.Error, "导出对话失败")
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/request"
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/pkg/importer"
+	"ai-qa-backend/internal/service"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportFileSize = 50 << 20
+
+type ImportHandler struct {
+	importService service.ImportService
+}
+
+func NewImportHandler(importService service.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+func (h *ImportHandler) Import(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	var req request.ImportConversations
+	if err := c.ShouldBind(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "请上传导入文件")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无法读取导入文件")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无法读取导入文件")
+		return
+	}
+
+	job, err := h.importService.StartImport(userID.(uint), data, req.Format, req.CategoryID)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else if errors.Is(err, importer.ErrUnsupportedFormat) || errors.Is(err, importer.ErrEmptyImport) || strings.HasPrefix(err.Error(), "invalid") {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, "创建导入任务失败")
+		}
+		return
+	}
+
+	response.Success(c, transformJobToDTO(job))
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/service"
+	"encoding/json"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobService service.JobService
+}
+
+func NewJobHandler(jobService service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+func (h *JobHandler) Get(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的任务ID")
+		return
+	}
+
+	job, err := h.jobService.GetJob(uint(jobID), userID.(uint))
+	if err != nil {
+		response.Fail(c, e.NotFound, err.Error())
+		return
+	}
+
+	response.Success(c, transformJobToDTO(job))
+}
+
+func transformJobToDTO(job *model.Job) *response.JobInfo {
+	info := &response.JobInfo{
+		ID:         job.ID,
+		Type:       job.Type,
+		Status:     job.Status,
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Results != "" {
+		info.Results = json.RawMessage(job.Results)
+	}
+	return info
+}
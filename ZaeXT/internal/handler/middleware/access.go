@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/service"
+	"errors"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func UserAccessMiddleware(userService service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+
+		access, err := userService.ResolveAccess(userID.(uint))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response.Fail(c, e.Unauthorized, "用户不存在，请重新登录")
+			} else {
+				log.Printf("Failed to resolve access for user %d: %v", userID, err)
+				response.Fail(c, e.Error, "获取用户信息失败")
+			}
+			c.Abort()
+			return
+		}
+
+		if access.Disabled {
+			response.Fail(c, e.PermissionDenied, "账号已被禁用")
+			c.Abort()
+			return
+		}
+
+		c.Set("userTier", access.Tier)
+		c.Set("userRole", access.Role)
+		c.Set("passwordResetRequired", access.PasswordResetRequired)
+		c.Next()
+	}
+}
+
+func PasswordResetMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("passwordResetRequired") {
+			response.Fail(c, e.PermissionDenied, "管理员要求重置密码，请先修改密码")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("userRole") != model.RoleAdmin {
+			response.Fail(c, e.PermissionDenied, "需要管理员权限")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
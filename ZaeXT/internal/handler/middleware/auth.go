@@ -5,12 +5,14 @@ import (
 	"ai-qa-backend/internal/handler/response"
 	"ai-qa-backend/internal/pkg/e"
 	"ai-qa-backend/internal/pkg/jwt"
+	"ai-qa-backend/internal/service"
+	"log"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 	jwtHelper := jwt.NewJWT(configs.Conf.JWT.Secret)
 
 	return func(c *gin.Context) {
@@ -29,14 +31,27 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		claims, err := jwtHelper.ParseToken(parts[1])
-		if err != nil {
+		if err != nil || claims.ID == "" || claims.SessionID == 0 {
 			response.Fail(c, e.Unauthorized, "token无效或已过期")
 			c.Abort()
 			return
 		}
 
+		revoked, err := authService.IsTokenRevoked(claims.ID, claims.SessionID)
+		if err != nil {
+			log.Printf("Failed to check token revocation for user %d: %v", claims.UserID, err)
+			response.Fail(c, e.Error, "校验token失败")
+			c.Abort()
+			return
+		}
+		if revoked {
+			response.Fail(c, e.Unauthorized, "token已失效，请重新登录")
+			c.Abort()
+			return
+		}
+
 		c.Set("userID", claims.UserID)
-		c.Set("userTier", claims.UserTier)
+		c.Set("sessionID", claims.SessionID)
 		c.Next()
 	}
 }
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"ai-qa-backend/internal/handler/request"
 	"ai-qa-backend/internal/handler/response"
 	"ai-qa-backend/internal/pkg/e"
 	"ai-qa-backend/internal/service"
 
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -39,15 +41,33 @@ func (h *RecycleBinHandler) List(c *gin.Context) {
 			Title:       conv.Title,
 			IsTemporary: conv.IsTemporary,
 			CategoryID:  conv.CategoryID,
+			PinnedAt:    conv.PinnedAt,
+			ArchivedAt:  conv.ArchivedAt,
 			CreatedAt:   conv.CreatedAt,
 			UpdatedAt:   conv.UpdatedAt,
 			DeletedAt:   deletedAt,
+			PurgeAt:     conv.PurgeAt,
 		}
 	}
 
 	response.Success(c, convInfos)
 }
 
+func (h *RecycleBinHandler) Empty(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	result, err := h.recycleBinService.Empty(userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "清空回收站失败")
+		return
+	}
+
+	response.Success(c, &response.RecycleBinEmptyResult{
+		Conversations: result.Conversations,
+		Categories:    result.Categories,
+	})
+}
+
 func (h *RecycleBinHandler) Restore(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
@@ -73,7 +93,116 @@ func (h *RecycleBinHandler) PermanentDelete(c *gin.Context) {
 	}
 
 	if err := h.recycleBinService.PermanentDelete(uint(convID), userID.(uint)); err != nil {
-		response.Fail(c, e.Error, "永久删除对话失败")
+		if strings.Contains(err.Error(), "not found") {
+			response.Fail(c, e.NotFound, err.Error())
+		} else {
+			response.Fail(c, e.Error, "永久删除对话失败")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *RecycleBinHandler) BatchRestore(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.BatchConversationIDs
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	results, err := h.recycleBinService.BatchRestore(req.IDs, userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "批量恢复对话失败")
+		return
+	}
+
+	response.Success(c, transformBatchResultToDTO(results))
+}
+
+func (h *RecycleBinHandler) BatchPermanentDelete(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.BatchConversationIDs
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	results, err := h.recycleBinService.BatchPermanentDelete(req.IDs, userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "批量永久删除对话失败")
+		return
+	}
+
+	response.Success(c, transformBatchResultToDTO(results))
+}
+
+func (h *RecycleBinHandler) ListCategories(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	categories, err := h.recycleBinService.ListCategories(userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "获取回收站分类列表失败")
+		return
+	}
+
+	categoryInfos := make([]*response.CategoryInfo, len(categories))
+	for i, category := range categories {
+		deletedAt := category.DeletedAt.Time
+		categoryInfos[i] = &response.CategoryInfo{
+			ID:        category.ID,
+			Name:      category.Name,
+			ParentID:  category.ParentID,
+			Position:  category.Position,
+			DeletedAt: &deletedAt,
+			PurgeAt:   category.PurgeAt,
+		}
+	}
+
+	response.Success(c, categoryInfos)
+}
+
+func (h *RecycleBinHandler) RestoreCategory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的分类ID")
+		return
+	}
+
+	result, err := h.recycleBinService.RestoreCategory(uint(categoryID), userID.(uint))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			response.Fail(c, e.NotFound, err.Error())
+		} else {
+			response.Fail(c, e.Error, "恢复分类失败")
+		}
+		return
+	}
+
+	response.Success(c, &response.CategoryRestoreResult{
+		Categories:    result.Categories,
+		Conversations: result.Conversations,
+	})
+}
+
+func (h *RecycleBinHandler) PermanentDeleteCategory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的分类ID")
+		return
+	}
+
+	if err := h.recycleBinService.PermanentDeleteCategory(uint(categoryID), userID.(uint)); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			response.Fail(c, e.NotFound, err.Error())
+		} else {
+			response.Fail(c, e.Error, "永久删除分类失败")
+		}
 		return
 	}
 
@@ -0,0 +1,30 @@
+package request
+
+type AdminListUsers struct {
+	Query    string `form:"q" binding:"max=64"`
+	Tier     string `form:"tier" binding:"max=20"`
+	Role     string `form:"role" binding:"omitempty,oneof=user admin"`
+	Disabled *bool  `form:"disabled"`
+	Cursor   string `form:"cursor"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+type AdminUpdateTier struct {
+	Tier string `json:"tier" binding:"required,max=20"`
+}
+
+type AdminUpdateStatus struct {
+	Disabled *bool `json:"disabled" binding:"required"`
+}
+
+type AdminUpdatePasswordReset struct {
+	Required *bool `json:"required" binding:"required"`
+}
+
+type AdminListAuditLogs struct {
+	AdminID      *uint  `form:"admin_id"`
+	TargetUserID *uint  `form:"target_user_id"`
+	Action       string `form:"action" binding:"max=50"`
+	Cursor       string `form:"cursor"`
+	Limit        int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type BatchConversationIDs struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=500"`
+}
+
+type BatchMoveConversations struct {
+	IDs        []uint `json:"ids" binding:"required,min=1,max=500"`
+	CategoryID *uint  `json:"category_id"`
+}
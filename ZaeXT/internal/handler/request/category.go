@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 type CreateCategory struct {
 	Name     string `json:"name" binding:"required,max=100"`
 	ParentID *uint  `json:"parent_id"`
@@ -9,3 +11,28 @@ type UpdateCategory struct {
 	Name     string `json:"name" binding:"required,max=100"`
 	ParentID *uint  `json:"parent_id"`
 }
+
+type ListCategories struct {
+	MaxDepth  int  `form:"max_depth" binding:"omitempty,min=0"`
+	WithStats bool `form:"with_stats"`
+}
+
+type UpdateCategoryPosition struct {
+	Position *int `json:"position" binding:"required,min=0"`
+}
+
+type ReorderCategories struct {
+	ParentID *uint  `json:"parent_id"`
+	IDs      []uint `json:"ids" binding:"required,min=1"`
+}
+
+type DeleteCategory struct {
+	Strategy string `form:"strategy"`
+	Preview  bool   `form:"preview"`
+}
+
+type CategoryActivity struct {
+	Bucket string     `form:"bucket" binding:"omitempty,oneof=day week month"`
+	From   *time.Time `form:"from" time_format:"2006-01-02" time_location:"Local"`
+	To     *time.Time `form:"to" time_format:"2006-01-02" time_location:"Local"`
+}
@@ -3,7 +3,7 @@ package request
 type ChatMessage struct {
 	Message        string `json:"message" binding:"required,max=5000"`
 	ModelID        string `json:"model_id,omitempty"`
-	EnableThinking bool   `json:"enable_thinking,omitempty"`
+	EnableThinking *bool  `json:"enable_thinking,omitempty"`
 }
 
 type CreateConversation struct {
@@ -14,3 +14,13 @@ type CreateConversation struct {
 type UpdateTitle struct {
 	Title string `json:"title" binding:"max=255"`
 }
+
+type StartAutoClassify struct {
+	ConversationIDs []uint `json:"conversation_ids" binding:"max=500"`
+	DryRun          bool   `json:"dry_run"`
+}
+
+type ConfirmProposedCategory struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID *uint  `json:"parent_id"`
+}
@@ -3,3 +3,44 @@ package request
 type UpdateConversationCategory struct {
 	CategoryID *uint `json:"category_id"`
 }
+
+type ListConversations struct {
+	CategoryID         *uint  `form:"category_id"`
+	IncludeDescendants bool   `form:"include_descendants"`
+	Uncategorized      bool   `form:"uncategorized"`
+	TagIDs             []uint `form:"tag_id"`
+	Query              string `form:"q" binding:"max=255"`
+	Archived           string `form:"archived" binding:"omitempty,oneof=exclude include only"`
+	Sort               string `form:"sort" binding:"omitempty,oneof=created_at updated_at"`
+	Order              string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Cursor             string `form:"cursor"`
+	Limit              int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ExportConversation struct {
+	Format           string `form:"format" binding:"omitempty,oneof=md json html"`
+	IncludeReasoning bool   `form:"include_reasoning"`
+}
+
+type ExportConversations struct {
+	IDs              []uint `json:"ids" binding:"required,min=1,max=500"`
+	Format           string `json:"format" binding:"omitempty,oneof=md json html"`
+	IncludeReasoning bool   `json:"include_reasoning"`
+}
+
+type ImportConversations struct {
+	Format     string `form:"format" binding:"omitempty,oneof=auto native chatgpt"`
+	CategoryID *uint  `form:"category_id"`
+}
+
+type ForkConversation struct {
+	MessageID *uint `json:"message_id"`
+}
+
+type PinConversation struct {
+	Pinned bool `json:"pinned"`
+}
+
+type ArchiveConversation struct {
+	Archived bool `json:"archived"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type CreateShare struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=8760"`
+}
+
+type UpdateShare struct {
+	ExpiresInHours  *int `json:"expires_in_hours" binding:"omitempty,min=0,max=8760"`
+	RefreshSnapshot bool `json:"refresh_snapshot"`
+}
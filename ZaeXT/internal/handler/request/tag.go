@@ -0,0 +1,15 @@
+package request
+
+type CreateTag struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"omitempty,hexcolor,len=7"`
+}
+
+type UpdateTag struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"omitempty,hexcolor,len=7"`
+}
+
+type TagConversation struct {
+	TagIDs []uint `json:"tag_ids" binding:"required,min=1"`
+}
@@ -2,7 +2,7 @@ package request
 
 type UserRegister struct {
 	Username string `json:"username" binding:"required,min=3,max=50"`
-	Password string `json:"password" binding:"required,min=6,max=50"`
+	Password string `json:"password" binding:"required,max=72"`
 }
 
 type UserLogin struct {
@@ -10,6 +10,28 @@ type UserLogin struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshToken struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 type UpdateUserMemory struct {
 	MemoryInfo string `json:"memory_info" binding:"max=5000"`
 }
+
+type UpdateRetention struct {
+	RetentionDays *int `json:"retention_days"`
+}
+
+type ChangePassword struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,max=72"`
+}
+
+type ForgotPassword struct {
+	Username string `json:"username" binding:"required,max=64"`
+}
+
+type ResetPassword struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,max=72"`
+}
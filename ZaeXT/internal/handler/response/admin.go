@@ -0,0 +1,39 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AdminUserInfo struct {
+	ID                    uint       `json:"id"`
+	Username              string     `json:"username"`
+	Tier                  string     `json:"tier"`
+	Role                  string     `json:"role"`
+	Disabled              bool       `json:"disabled"`
+	DisabledAt            *time.Time `json:"disabled_at,omitempty"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	CreatedAt             time.Time  `json:"created_at"`
+	Usage                 *UserUsage `json:"usage,omitempty"`
+}
+
+type UserUsage struct {
+	Conversations         int64      `json:"conversations"`
+	ArchivedConversations int64      `json:"archived_conversations"`
+	TrashedConversations  int64      `json:"trashed_conversations"`
+	Categories            int64      `json:"categories"`
+	Messages              int64      `json:"messages"`
+	RecentMessages        int64      `json:"recent_messages"`
+	LastActiveAt          *time.Time `json:"last_active_at,omitempty"`
+	ActiveSessions        int64      `json:"active_sessions"`
+}
+
+type AdminAuditLog struct {
+	ID           uint            `json:"id"`
+	AdminID      *uint           `json:"admin_id"`
+	TargetUserID *uint           `json:"target_user_id,omitempty"`
+	Action       string          `json:"action"`
+	Details      json.RawMessage `json:"details,omitempty"`
+	IP           string          `json:"ip,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
@@ -1,8 +1,47 @@
 package response
 
+import "time"
+
 type CategoryInfo struct {
-	ID       uint            `json:"id"`
-	Name     string          `json:"name"`
-	ParentID *uint           `json:"parent_id,omitempty"`
-	Children []*CategoryInfo `json:"children,omitempty"`
+	ID        uint            `json:"id"`
+	Name      string          `json:"name"`
+	ParentID  *uint           `json:"parent_id,omitempty"`
+	Position  int             `json:"position"`
+	Path      []string        `json:"path,omitempty"`
+	Depth     int             `json:"depth,omitempty"`
+	Stats     *CategoryStats  `json:"stats,omitempty"`
+	DeletedAt *time.Time      `json:"deleted_at,omitempty"`
+	PurgeAt   *time.Time      `json:"purge_at,omitempty"`
+	Children  []*CategoryInfo `json:"children,omitempty"`
+}
+
+type CategoryDeleteResult struct {
+	Strategy      string `json:"strategy"`
+	Preview       bool   `json:"preview"`
+	Categories    int64  `json:"categories"`
+	Conversations int64  `json:"conversations"`
+}
+
+type CategoryStats struct {
+	DirectConversations int64      `json:"direct_conversations"`
+	TotalConversations  int64      `json:"total_conversations"`
+	Messages            int64      `json:"messages"`
+	LastActivityAt      *time.Time `json:"last_activity_at,omitempty"`
+}
+
+type CategoryActivity struct {
+	CategoryID    *uint  `json:"category_id"`
+	Period        string `json:"period"`
+	Conversations int64  `json:"conversations"`
+	Messages      int64  `json:"messages"`
+}
+
+type CategoryRestoreResult struct {
+	Categories    int64 `json:"categories"`
+	Conversations int64 `json:"conversations"`
+}
+
+type RecycleBinEmptyResult struct {
+	Conversations int64 `json:"conversations"`
+	Categories    int64 `json:"categories"`
 }
@@ -3,11 +3,18 @@ package response
 import "time"
 
 type ConversationInfo struct {
-	ID          uint       `json:"id"`
-	Title       string     `json:"title"`
-	IsTemporary bool       `json:"is_temporary"`
-	CategoryID  *uint      `json:"category_id"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	ID             uint       `json:"id"`
+	Title          string     `json:"title"`
+	IsTemporary    bool       `json:"is_temporary"`
+	CategoryID     *uint      `json:"category_id"`
+	ModelID        string     `json:"model_id,omitempty"`
+	EnableThinking bool       `json:"enable_thinking"`
+	Summary        string     `json:"summary,omitempty"`
+	PinnedAt       *time.Time `json:"pinned_at,omitempty"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+	Tags           []*TagInfo `json:"tags,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	PurgeAt        *time.Time `json:"purge_at,omitempty"`
 }
@@ -3,8 +3,10 @@ package response
 import "time"
 
 type MessageInfo struct {
-	ID        uint      `json:"id"`
-	Role      string    `json:"role"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             uint      `json:"id"`
+	Role           string    `json:"role"`
+	Content        string    `json:"content"`
+	ModelID        string    `json:"model_id,omitempty"`
+	EnableThinking bool      `json:"enable_thinking,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
 }
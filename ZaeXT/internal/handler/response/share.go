@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type ShareInfo struct {
+	ID             uint       `json:"id"`
+	ConversationID uint       `json:"conversation_id"`
+	Token          string     `json:"token"`
+	Path           string     `json:"path"`
+	ViewCount      int64      `json:"view_count"`
+	SnapshotAt     time.Time  `json:"snapshot_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
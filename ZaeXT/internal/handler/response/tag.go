@@ -0,0 +1,7 @@
+package response
+
+type TagInfo struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
@@ -3,9 +3,26 @@ package response
 import "time"
 
 type UserProfile struct {
-	ID         uint      `json:"id"`
-	Username   string    `json:"username"`
-	Tier       string    `json:"tier"`
-	MemoryInfo string    `json:"memory_info"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         uint             `json:"id"`
+	Username   string           `json:"username"`
+	Tier       string           `json:"tier"`
+	MemoryInfo string           `json:"memory_info"`
+	Retention  RetentionSetting `json:"retention"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type RetentionSetting struct {
+	Days       int  `json:"days"`
+	CustomDays *int `json:"custom_days"`
+	MinDays    int  `json:"min_days"`
+	MaxDays    int  `json:"max_days"`
+}
+
+type TokenPair struct {
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+
+	PasswordResetRequired bool `json:"password_reset_required"`
 }
@@ -15,26 +15,47 @@ func SetupRouter(services *service.Service) *gin.Engine {
 		AllowAllOrigins: true,
 		AllowMethods:    []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowHeaders:    []string{"Origin", "Content-Type", "Authorization", "Accept"},
-		ExposeHeaders:   []string{"Content-Length"},
+		ExposeHeaders:   []string{"Content-Length", "Content-Disposition", "X-Next-Cursor"},
 		MaxAge:          12 * time.Hour,
 	}
 	router.Use(cors.New(config))
 
 	apiV1 := router.Group("/api/v1")
 
+	adminHandler := NewAdminHandler(services.Admin)
+	authHandler := NewAuthHandler(services.Auth)
 	userHandler := NewUserHandler(services.User)
 	chatHandler := NewChatHandler(services.Chat)
 	categoryHandler := NewCategoryHandler(services.Category)
 	recycleBinHandler := NewRecycleBinHandler(services.RecycleBin)
+	exportHandler := NewExportHandler(services.Export)
+	importHandler := NewImportHandler(services.Import)
+	jobHandler := NewJobHandler(services.Job)
+	shareHandler := NewShareHandler(services.Share)
+	tagHandler := NewTagHandler(services.Tag)
+	taskHandler := NewTaskHandler(services.Task)
 
 	apiV1.POST("/register", userHandler.Register)
 	apiV1.POST("/login", userHandler.Login)
+	apiV1.POST("/auth/refresh", authHandler.Refresh)
+	apiV1.POST("/password/forgot", userHandler.ForgotPassword)
+	apiV1.POST("/password/reset", userHandler.ResetPassword)
+	apiV1.GET("/shared/:token", shareHandler.GetShared)
+
+	accountGroup := apiV1.Group("")
+	accountGroup.Use(middleware.AuthMiddleware(services.Auth), middleware.UserAccessMiddleware(services.User))
+	{
+		accountGroup.POST("/auth/logout", authHandler.Logout)
+		accountGroup.POST("/auth/logout-all", authHandler.LogoutAll)
+		accountGroup.GET("/profile", userHandler.GetProfile)
+		accountGroup.PUT("/profile/password", userHandler.ChangePassword)
+	}
 
 	authGroup := apiV1.Group("")
-	authGroup.Use(middleware.AuthMiddleware())
+	authGroup.Use(middleware.AuthMiddleware(services.Auth), middleware.UserAccessMiddleware(services.User), middleware.PasswordResetMiddleware())
 	{
-		authGroup.GET("/profile", userHandler.GetProfile)
 		authGroup.PUT("/profile/memory", userHandler.UpdateMemory)
+		authGroup.PUT("/profile/retention", userHandler.UpdateRetention)
 		authGroup.GET("/models", chatHandler.ListModels)
 		authGroup.POST("/conversations", chatHandler.CreateConversation)
 		authGroup.GET("/conversations", chatHandler.ListConversations)
@@ -42,15 +63,58 @@ func SetupRouter(services *service.Service) *gin.Engine {
 		authGroup.GET("/conversations/:id/messages", chatHandler.GetMessages)
 		authGroup.PUT("/conversations/:id/title", chatHandler.UpdateTitle)
 		authGroup.PUT("/conversations/:id/category", chatHandler.UpdateConversationCategory)
+		authGroup.PUT("/conversations/:id/pin", chatHandler.PinConversation)
+		authGroup.PUT("/conversations/:id/archive", chatHandler.ArchiveConversation)
 		authGroup.DELETE("/conversations/:id", chatHandler.DeleteConversation)
+		authGroup.POST("/conversations/:id/end", chatHandler.EndTemporaryConversation)
+		authGroup.POST("/conversations/:id/fork", chatHandler.ForkConversation)
+		authGroup.GET("/conversations/:id/export", exportHandler.Export)
+		authGroup.POST("/conversations/export", exportHandler.ExportArchive)
+		authGroup.POST("/conversations/import", importHandler.Import)
+		authGroup.POST("/conversations/batch/move", chatHandler.BatchMoveConversations)
+		authGroup.POST("/conversations/batch/delete", chatHandler.BatchDeleteConversations)
+		authGroup.POST("/conversations/:id/share", shareHandler.Create)
+		authGroup.GET("/conversations/:id/shares", shareHandler.List)
+		authGroup.GET("/conversations/:id/tasks", taskHandler.ListConversationTasks)
+		authGroup.PUT("/shares/:id", shareHandler.Update)
+		authGroup.DELETE("/shares/:id", shareHandler.Revoke)
+		authGroup.GET("/jobs/:id", jobHandler.Get)
 		authGroup.POST("/conversations/:id/auto-classify", chatHandler.AutoClassify)
+		authGroup.POST("/conversations/:id/auto-classify/confirm", chatHandler.ConfirmProposedCategory)
+		authGroup.POST("/conversations/auto-classify", chatHandler.StartAutoClassify)
+		authGroup.POST("/conversations/:id/tags", tagHandler.TagConversation)
+		authGroup.DELETE("/conversations/:id/tags/:tag_id", tagHandler.UntagConversation)
 		authGroup.POST("/categories", categoryHandler.Create)
 		authGroup.GET("/categories", categoryHandler.List)
+		authGroup.GET("/categories/stats", categoryHandler.Activity)
+		authGroup.PUT("/categories/reorder", categoryHandler.Reorder)
 		authGroup.PUT("/categories/:id", categoryHandler.Update)
+		authGroup.PUT("/categories/:id/position", categoryHandler.UpdatePosition)
 		authGroup.DELETE("/categories/:id", categoryHandler.Delete)
+		authGroup.POST("/tags", tagHandler.Create)
+		authGroup.GET("/tags", tagHandler.List)
+		authGroup.PUT("/tags/:id", tagHandler.Update)
+		authGroup.DELETE("/tags/:id", tagHandler.Delete)
 		authGroup.GET("/recycle-bin", recycleBinHandler.List)
+		authGroup.DELETE("/recycle-bin", recycleBinHandler.Empty)
 		authGroup.POST("/recycle-bin/restore/:id", recycleBinHandler.Restore)
 		authGroup.DELETE("/recycle-bin/permanent/:id", recycleBinHandler.PermanentDelete)
+		authGroup.POST("/recycle-bin/batch/restore", recycleBinHandler.BatchRestore)
+		authGroup.POST("/recycle-bin/batch/permanent-delete", recycleBinHandler.BatchPermanentDelete)
+		authGroup.GET("/recycle-bin/categories", recycleBinHandler.ListCategories)
+		authGroup.POST("/recycle-bin/categories/restore/:id", recycleBinHandler.RestoreCategory)
+		authGroup.DELETE("/recycle-bin/categories/permanent/:id", recycleBinHandler.PermanentDeleteCategory)
+	}
+
+	adminGroup := apiV1.Group("/admin")
+	adminGroup.Use(middleware.AuthMiddleware(services.Auth), middleware.UserAccessMiddleware(services.User), middleware.PasswordResetMiddleware(), middleware.AdminMiddleware())
+	{
+		adminGroup.GET("/users", adminHandler.ListUsers)
+		adminGroup.GET("/users/:id", adminHandler.GetUser)
+		adminGroup.PUT("/users/:id/tier", adminHandler.UpdateTier)
+		adminGroup.PUT("/users/:id/status", adminHandler.UpdateStatus)
+		adminGroup.PUT("/users/:id/password-reset", adminHandler.UpdatePasswordReset)
+		adminGroup.GET("/audit-logs", adminHandler.ListAuditLogs)
 	}
 
 	return router
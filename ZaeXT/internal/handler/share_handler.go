@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/request"
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/service"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareHandler struct {
+	shareService service.ShareService
+}
+
+func NewShareHandler(shareService service.ShareService) *ShareHandler {
+	return &ShareHandler{shareService: shareService}
+}
+
+func (h *ShareHandler) Create(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.CreateShare
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	share, err := h.shareService.CreateShare(uint(convID), userID.(uint), time.Duration(req.ExpiresInHours)*time.Hour)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, transformShareToDTO(share))
+}
+
+func (h *ShareHandler) List(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	shares, err := h.shareService.ListShares(uint(convID), userID.(uint))
+	if err != nil {
+		response.Fail(c, e.PermissionDenied, err.Error())
+		return
+	}
+
+	shareInfos := make([]*response.ShareInfo, len(shares))
+	for i, share := range shares {
+		shareInfos[i] = transformShareToDTO(share)
+	}
+	response.Success(c, shareInfos)
+}
+
+func (h *ShareHandler) Update(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	shareID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的分享ID")
+		return
+	}
+
+	var req request.UpdateShare
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	var expiresIn *time.Duration
+	if req.ExpiresInHours != nil {
+		d := time.Duration(*req.ExpiresInHours) * time.Hour
+		expiresIn = &d
+	}
+
+	share, err := h.shareService.UpdateShare(uint(shareID), userID.(uint), expiresIn, req.RefreshSnapshot)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, transformShareToDTO(share))
+}
+
+func (h *ShareHandler) Revoke(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	shareID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的分享ID")
+		return
+	}
+
+	if err := h.shareService.RevokeShare(uint(shareID), userID.(uint)); err != nil {
+		response.Fail(c, e.PermissionDenied, err.Error())
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *ShareHandler) GetShared(c *gin.Context) {
+	shared, err := h.shareService.GetSharedConversation(c.Param("token"))
+	if err != nil {
+		response.Fail(c, e.NotFound, "分享链接不存在或已失效")
+		return
+	}
+
+	response.Success(c, shared)
+}
+
+func transformShareToDTO(share *model.ConversationShare) *response.ShareInfo {
+	return &response.ShareInfo{
+		ID:             share.ID,
+		ConversationID: share.ConversationID,
+		Token:          share.Token,
+		Path:           "/api/v1/shared/" + share.Token,
+		ViewCount:      share.ViewCount,
+		SnapshotAt:     share.SnapshotAt,
+		ExpiresAt:      share.ExpiresAt,
+		RevokedAt:      share.RevokedAt,
+		CreatedAt:      share.CreatedAt,
+	}
+}
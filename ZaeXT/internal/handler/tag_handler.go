@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/request"
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/service"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+func (h *TagHandler) Create(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req request.CreateTag
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	tag, err := h.tagService.Create(userID.(uint), req.Name, req.Color)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else if errors.Is(err, service.ErrEmptyTagName) {
+			response.Fail(c, e.InvalidParams, "标签名称不能为空")
+		} else {
+			response.Fail(c, e.Error, "创建标签失败")
+		}
+		return
+	}
+
+	response.Success(c, transformTagsToDTO([]*model.Tag{tag})[0])
+}
+
+func (h *TagHandler) List(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	tags, err := h.tagService.List(userID.(uint))
+	if err != nil {
+		response.Fail(c, e.Error, "获取标签列表失败")
+		return
+	}
+
+	tagInfos := transformTagsToDTO(tags)
+	if tagInfos == nil {
+		tagInfos = []*response.TagInfo{}
+	}
+	response.Success(c, tagInfos)
+}
+
+func (h *TagHandler) Update(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	tagID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的标签ID")
+		return
+	}
+
+	var req request.UpdateTag
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.tagService.Update(uint(tagID), userID.(uint), req.Name, req.Color); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *TagHandler) Delete(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	tagID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的标签ID")
+		return
+	}
+
+	if err := h.tagService.Delete(uint(tagID), userID.(uint)); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *TagHandler) TagConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	var req request.TagConversation
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.tagService.TagConversation(uint(convID), userID.(uint), req.TagIDs); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *TagHandler) UntagConversation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+	tagID, err := strconv.ParseUint(c.Param("tag_id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的标签ID")
+		return
+	}
+
+	if err := h.tagService.UntagConversation(uint(convID), userID.(uint), uint(tagID)); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+func (h *TagHandler) fail(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "permission denied") {
+		response.Fail(c, e.PermissionDenied, err.Error())
+	} else if strings.Contains(err.Error(), "already exists") {
+		response.Fail(c, e.InvalidParams, err.Error())
+	} else if errors.Is(err, service.ErrEmptyTagName) {
+		response.Fail(c, e.InvalidParams, "标签名称不能为空")
+	} else {
+		response.Fail(c, e.Error, err.Error())
+	}
+}
+
+func transformTagsToDTO(tags []*model.Tag) []*response.TagInfo {
+	if tags == nil {
+		return nil
+	}
+	dtos := make([]*response.TagInfo, len(tags))
+	for i, tag := range tags {
+		dtos[i] = &response.TagInfo{
+			ID:    tag.ID,
+			Name:  tag.Name,
+			Color: tag.Color,
+		}
+	}
+	return dtos
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/service"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskHandler struct {
+	taskService service.TaskService
+}
+
+func NewTaskHandler(taskService service.TaskService) *TaskHandler {
+	return &TaskHandler{taskService: taskService}
+}
+
+func (h *TaskHandler) ListConversationTasks(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	convID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Fail(c, e.InvalidParams, "无效的对话ID")
+		return
+	}
+
+	tasks, err := h.taskService.ListConversationTasks(uint(convID), userID.(uint))
+	if err != nil {
+		if strings.Contains(err.Error(), "permission denied") {
+			response.Fail(c, e.PermissionDenied, err.Error())
+		} else {
+			response.Fail(c, e.Error, "获取后台任务失败")
+		}
+		return
+	}
+
+	taskInfos := make([]*response.TaskInfo, len(tasks))
+	for i, task := range tasks {
+		taskInfos[i] = &response.TaskInfo{
+			ID:          task.ID,
+			Type:        task.Type,
+			Status:      task.Status,
+			Attempts:    task.Attempts,
+			MaxAttempts: task.MaxAttempts,
+			LastError:   task.LastError,
+			RunAt:       task.RunAt,
+			CreatedAt:   task.CreatedAt,
+			UpdatedAt:   task.UpdatedAt,
+			FinishedAt:  task.FinishedAt,
+		}
+	}
+
+	response.Success(c, taskInfos)
+}
@@ -3,9 +3,12 @@ package handler
 import (
 	"ai-qa-backend/internal/handler/request"
 	"ai-qa-backend/internal/handler/response"
+	"ai-qa-backend/internal/model"
 	"ai-qa-backend/internal/pkg/e"
+	"ai-qa-backend/internal/pkg/password"
 	"ai-qa-backend/internal/service"
 	"errors"
+	"fmt"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -27,7 +30,11 @@ func (h *UserHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.userService.Register(req.Username, req.Password); err != nil {
-		response.Fail(c, e.Error, err.Error())
+		if errors.Is(err, password.ErrWeakPassword) {
+			response.Fail(c, e.InvalidParams, err.Error())
+		} else {
+			response.Fail(c, e.Error, err.Error())
+		}
 		return
 	}
 
@@ -41,14 +48,18 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.userService.Login(req.Username, req.Password)
+	tokens, err := h.userService.Login(req.Username, req.Password, sessionMeta(c))
 	if err != nil {
-		response.Fail(c, e.Unauthorized, err.Error())
+		switch {
+		case errors.Is(err, service.ErrAccountDisabled):
+			response.Fail(c, e.PermissionDenied, "账号已被禁用")
+		default:
+			response.Fail(c, e.Unauthorized, err.Error())
+		}
 		return
 	}
 
-	response.Success(c, gin.H{"token": token})
-
+	response.Success(c, transformTokenPairToDTO(tokens))
 }
 
 func (h *UserHandler) GetProfile(c *gin.Context) {
@@ -70,6 +81,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		Username:   user.Username,
 		Tier:       user.Tier,
 		MemoryInfo: user.MemoryInfo,
+		Retention:  transformRetentionToDTO(user),
 		CreatedAt:  user.CreatedAt,
 	}
 	response.Success(c, userProfile)
@@ -92,3 +104,101 @@ func (h *UserHandler) UpdateMemory(c *gin.Context) {
 
 	response.Success(c, nil)
 }
+
+func (h *UserHandler) UpdateRetention(c *gin.Context) {
+	userIDVal, _ := c.Get("userID")
+	userID := userIDVal.(uint)
+
+	var req request.UpdateRetention
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	user, err := h.userService.UpdateRetention(userID, req.RetentionDays)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRetention):
+			response.Fail(c, e.InvalidParams, fmt.Sprintf("保留天数超出允许范围: %v", err))
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			response.Fail(c, e.NotFound, "用户不存在")
+		default:
+			response.Fail(c, e.Error, "更新回收站保留天数失败")
+		}
+		return
+	}
+
+	response.Success(c, transformRetentionToDTO(user))
+}
+
+func transformRetentionToDTO(user *model.User) response.RetentionSetting {
+	minDays, maxDays := service.RetentionBounds(user.Tier)
+	return response.RetentionSetting{
+		Days:       service.EffectiveRetentionDays(user),
+		CustomDays: user.RetentionDays,
+		MinDays:    max(minDays, 1),
+		MaxDays:    maxDays,
+	}
+}
+
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userIDVal, _ := c.Get("userID")
+	sessionIDVal, _ := c.Get("sessionID")
+
+	var req request.ChangePassword
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	revoked, err := h.userService.ChangePassword(userIDVal.(uint), sessionIDVal.(uint), req.OldPassword, req.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrIncorrectPassword):
+			response.Fail(c, e.InvalidParams, "原密码不正确")
+		case errors.Is(err, service.ErrSamePassword):
+			response.Fail(c, e.InvalidParams, "新密码不能与原密码相同")
+		case errors.Is(err, password.ErrWeakPassword):
+			response.Fail(c, e.InvalidParams, err.Error())
+		default:
+			response.Fail(c, e.Error, "修改密码失败")
+		}
+		return
+	}
+
+	response.Success(c, gin.H{"revoked_sessions": revoked})
+}
+
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPassword
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	h.userService.RequestPasswordReset(req.Username, c.ClientIP())
+
+	response.Success(c, nil)
+}
+
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPassword
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Fail(c, e.InvalidParams, err.Error())
+		return
+	}
+
+	if err := h.userService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidResetToken):
+			response.Fail(c, e.InvalidParams, "重置令牌无效或已过期")
+		case errors.Is(err, password.ErrWeakPassword):
+			response.Fail(c, e.InvalidParams, err.Error())
+		default:
+			response.Fail(c, e.Error, "重置密码失败")
+		}
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -0,0 +1,21 @@
+package model
+
+const (
+	AuditActionListUsers            = "list_users"
+	AuditActionViewUser             = "view_user"
+	AuditActionGrantRole            = "grant_role"
+	AuditActionUpdateTier           = "update_tier"
+	AuditActionDisableUser          = "disable_user"
+	AuditActionEnableUser           = "enable_user"
+	AuditActionRequirePasswordReset = "require_password_reset"
+	AuditActionCancelPasswordReset  = "cancel_password_reset"
+)
+
+type AdminAuditLog struct {
+	BaseModel
+	AdminID      *uint  `gorm:"index"`
+	TargetUserID *uint  `gorm:"index"`
+	Action       string `gorm:"size:50;not null;index"`
+	Details      string `gorm:"type:text"`
+	IP           string `gorm:"size:64"`
+}
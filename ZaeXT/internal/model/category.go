@@ -1,10 +1,14 @@
 package model
 
+import "gorm.io/gorm"
+
 type Category struct {
 	BaseModel
-	UserID   uint   `gorm:"not null;index"`
-	Name     string `gorm:"size:100;not null"`
-	ParentID *uint  `gorm:"index"`
+	UserID    uint           `gorm:"not null;index"`
+	Name      string         `gorm:"size:100;not null"`
+	ParentID  *uint          `gorm:"index"`
+	Position  int            `gorm:"not null;default:0"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 
 	User     User        `gorm:"foreignKey:UserID"`
 	Children []*Category `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
@@ -1,6 +1,10 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Conversation struct {
 	BaseModel
@@ -8,9 +12,16 @@ type Conversation struct {
 	Title               string         `gorm:"size:255;default:'New Chat'"`
 	IsTitleUserModified bool           `gorm:"default:false"`
 	CategoryID          *uint          `gorm:"index"`
+	TrashedCategoryID   *uint          `gorm:"index"`
 	IsTemporary         bool           `gorm:"default:false"`
+	ModelID             string         `gorm:"size:100"`
+	EnableThinking      bool           `gorm:"default:false"`
+	Summary             string         `gorm:"type:text"`
+	PinnedAt            *time.Time     `gorm:"index"`
+	ArchivedAt          *time.Time     `gorm:"index"`
 	DeletedAt           gorm.DeletedAt `gorm:"index"`
 
 	User     User       `gorm:"foreignKey:UserID"`
 	Messages []*Message `gorm:"foreignKey:ConversationID"`
+	Tags     []*Tag     `gorm:"many2many:conversation_tags"`
 }
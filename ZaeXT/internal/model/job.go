@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+type Job struct {
+	BaseModel
+	UserID      uint   `gorm:"not null;index"`
+	Type        string `gorm:"size:50;not null;index"`
+	Status      string `gorm:"size:20;not null;default:'pending'"`
+	Total       int    `gorm:"not null;default:0"`
+	Processed   int    `gorm:"not null;default:0"`
+	Failed      int    `gorm:"not null;default:0"`
+	Results     string `gorm:"type:longtext"`
+	Error       string `gorm:"type:text"`
+	Owner       string `gorm:"size:64;index"`
+	HeartbeatAt *time.Time
+	FinishedAt  *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
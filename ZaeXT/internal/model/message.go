@@ -2,9 +2,12 @@ package model
 
 type Message struct {
 	BaseModel
-	ConversationID uint   `gorm:"not null;index"`
-	Role           string `gorm:"size:20;not null"`
-	Content        string `gorm:"type:text;not null"`
+	ConversationID   uint   `gorm:"not null;index"`
+	Role             string `gorm:"size:20;not null"`
+	Content          string `gorm:"type:text;not null"`
+	ReasoningContent string `gorm:"type:text"`
+	ModelID          string `gorm:"size:100"`
+	EnableThinking   bool   `gorm:"default:false"`
 
 	Conversation Conversation `gorm:"foreignKey:ConversationID"`
 }
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type PasswordResetToken struct {
+	BaseModel
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	RequestIP string `gorm:"size:64"`
+
+	User User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+const (
+	PurgeRunStatusRunning   = "running"
+	PurgeRunStatusSucceeded = "succeeded"
+	PurgeRunStatusFailed    = "failed"
+)
+
+type PurgeRun struct {
+	BaseModel
+	Instance      string    `gorm:"size:128;not null"`
+	Status        string    `gorm:"size:20;not null;index"`
+	StartedAt     time.Time `gorm:"not null;index"`
+	FinishedAt    *time.Time
+	DurationMs    int64  `gorm:"not null;default:0"`
+	Batches       int    `gorm:"not null;default:0"`
+	Conversations int64  `gorm:"not null;default:0"`
+	Messages      int64  `gorm:"not null;default:0"`
+	Categories    int64  `gorm:"not null;default:0"`
+	LastError     string `gorm:"type:text"`
+}
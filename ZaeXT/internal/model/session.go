@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type Session struct {
+	BaseModel
+	UserID            uint      `gorm:"not null;index"`
+	RefreshTokenHash  string    `gorm:"size:64;not null;uniqueIndex"`
+	PreviousTokenHash string    `gorm:"size:64;index"`
+	AccessTokenID     string    `gorm:"size:64"`
+	AccessExpiresAt   time.Time `gorm:"not null"`
+	ExpiresAt         time.Time `gorm:"not null;index"`
+	LastUsedAt        time.Time `gorm:"not null"`
+	RevokedAt         *time.Time
+	UserAgent         string `gorm:"size:255"`
+	IP                string `gorm:"size:64"`
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+type RevokedToken struct {
+	TokenID   string    `gorm:"primaryKey;size:64"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type ConversationShare struct {
+	BaseModel
+	UserID         uint   `gorm:"not null;index"`
+	ConversationID uint   `gorm:"not null;index"`
+	Token          string `gorm:"size:64;not null;uniqueIndex"`
+	Snapshot       string `gorm:"type:longtext;not null"`
+	ViewCount      int64  `gorm:"not null;default:0"`
+	SnapshotAt     time.Time
+	ExpiresAt      *time.Time
+	RevokedAt      *time.Time
+
+	Conversation Conversation `gorm:"foreignKey:ConversationID"`
+}
@@ -0,0 +1,10 @@
+package model
+
+type Tag struct {
+	BaseModel
+	UserID uint   `gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	Name   string `gorm:"size:50;not null;uniqueIndex:idx_tags_user_name"`
+	Color  string `gorm:"size:7;not null;default:'#6B7280'"`
+
+	User User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	TaskStatusPending   = "pending"
+	TaskStatusRunning   = "running"
+	TaskStatusSucceeded = "succeeded"
+	TaskStatusDead      = "dead"
+)
+
+type Task struct {
+	BaseModel
+	UserID         uint      `gorm:"not null;index"`
+	ConversationID *uint     `gorm:"index"`
+	Type           string    `gorm:"size:50;not null"`
+	Status         string    `gorm:"size:20;not null;default:'pending';index:idx_tasks_status_run_at,priority:1"`
+	RunAt          time.Time `gorm:"not null;index:idx_tasks_status_run_at,priority:2"`
+	Attempts       int       `gorm:"not null;default:0"`
+	MaxAttempts    int       `gorm:"not null;default:5"`
+	LockedAt       *time.Time
+	LockedBy       string `gorm:"size:64"`
+	LastError      string `gorm:"type:text"`
+	FinishedAt     *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
@@ -1,11 +1,22 @@
 package model
 
+import "time"
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	BaseModel
-	Username     string `gorm:"unique;not null;size:64"`
-	PasswordHash string `gorm:"not null"`
-	Tier         string `gorm:"size:20;default:'free';not null"`
-	MemoryInfo   string `gorm:"type:text"`
+	Username              string `gorm:"unique;not null;size:64"`
+	PasswordHash          string `gorm:"not null"`
+	Tier                  string `gorm:"size:20;default:'free';not null"`
+	Role                  string `gorm:"size:20;default:'user';not null;index"`
+	MemoryInfo            string `gorm:"type:text"`
+	RetentionDays         *int
+	DisabledAt            *time.Time
+	PasswordResetRequired bool `gorm:"not null;default:false"`
 
 	Conversations []Conversation `gorm:"foreignKey:UserID"`
 	Categories    []Category     `gorm:"foreignKey:UserID"`
@@ -0,0 +1,102 @@
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+	FormatHTML     = "html"
+
+	DocumentVersion = 1
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+type Message struct {
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	Reasoning string    `json:"reasoning,omitempty"`
+	ModelID   string    `json:"model_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Document struct {
+	Version      int       `json:"version"`
+	ID           uint      `json:"id"`
+	Title        string    `json:"title"`
+	CategoryPath []string  `json:"category_path,omitempty"`
+	Models       []string  `json:"models,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	ExportedAt   time.Time `json:"exported_at"`
+	Messages     []Message `json:"messages"`
+}
+
+func IsSupported(format string) bool {
+	switch format {
+	case FormatMarkdown, FormatJSON, FormatHTML:
+		return true
+	}
+	return false
+}
+
+func Render(doc *Document, format string) ([]byte, error) {
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(doc), nil
+	case FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatHTML:
+		return renderHTML(doc)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func FileName(doc *Document, format string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', '\n', '\r', '\t':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(doc.Title))
+	if name == "" {
+		name = "conversation"
+	}
+	if len([]rune(name)) > 80 {
+		name = string([]rune(name)[:80])
+	}
+	return name + "." + format
+}
+
+func roleLabel(role string) string {
+	switch role {
+	case "user":
+		return "User"
+	case "assistant":
+		return "Assistant"
+	case "system":
+		return "System"
+	default:
+		return role
+	}
+}
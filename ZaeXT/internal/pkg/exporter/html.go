@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"time"
+)
+
+var htmlTemplate = template.Must(template.New("conversation").Funcs(template.FuncMap{
+	"role": roleLabel,
+	"time": func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; padding: 2rem 1rem; background: #f6f7f9; color: #1f2328; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; line-height: 1.6; }
+main { max-width: 860px; margin: 0 auto; }
+header { margin-bottom: 1.5rem; }
+h1 { margin: 0 0 .5rem; font-size: 1.6rem; }
+.meta { color: #656d76; font-size: .875rem; }
+.message { background: #fff; border: 1px solid #d0d7de; border-radius: 8px; padding: 1rem 1.25rem; margin-bottom: 1rem; }
+.message.user { border-left: 4px solid #0969da; }
+.message.assistant { border-left: 4px solid #1a7f37; }
+.message h2 { margin: 0 0 .25rem; font-size: 1rem; }
+.content { white-space: pre-wrap; word-wrap: break-word; }
+details { margin: .5rem 0; color: #656d76; }
+details .content { font-size: .9rem; }
+</style>
+</head>
+<body>
+<main>
+<header>
+<h1>{{.Title}}</h1>
+<div class="meta">
+{{if .CategoryPath}}<div>Category: {{join .CategoryPath " / "}}</div>{{end}}
+{{if .Models}}<div>Models: {{join .Models ", "}}</div>{{end}}
+<div>Created: {{time .CreatedAt}} · Updated: {{time .UpdatedAt}} · Exported: {{time .ExportedAt}}</div>
+</div>
+</header>
+{{range .Messages}}<section class="message {{.Role}}">
+<h2>{{role .Role}}</h2>
+<div class="meta">{{time .CreatedAt}}{{if .ModelID}} · {{.ModelID}}{{end}}</div>
+{{if .Reasoning}}<details><summary>Reasoning</summary><div class="content">{{.Reasoning}}</div></details>{{end}}
+<div class="content">{{.Content}}</div>
+</section>
+{{end}}</main>
+</body>
+</html>
+`))
+
+func renderHTML(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
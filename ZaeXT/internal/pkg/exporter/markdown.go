@@ -0,0 +1,40 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func renderMarkdown(doc *Document) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", doc.Title)
+	if len(doc.CategoryPath) > 0 {
+		fmt.Fprintf(&b, "- Category: %s\n", strings.Join(doc.CategoryPath, " / "))
+	}
+	if len(doc.Models) > 0 {
+		fmt.Fprintf(&b, "- Models: %s\n", strings.Join(doc.Models, ", "))
+	}
+	fmt.Fprintf(&b, "- Created: %s\n", doc.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Updated: %s\n", doc.UpdatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Exported: %s\n", doc.ExportedAt.Format(time.RFC3339))
+
+	for _, msg := range doc.Messages {
+		fmt.Fprintf(&b, "\n---\n\n## %s\n\n", roleLabel(msg.Role))
+		meta := msg.CreatedAt.Format(time.RFC3339)
+		if msg.ModelID != "" {
+			meta += " · " + msg.ModelID
+		}
+		fmt.Fprintf(&b, "_%s_\n\n", meta)
+		if msg.Reasoning != "" {
+			b.WriteString("<details>\n<summary>Reasoning</summary>\n\n")
+			b.WriteString(msg.Reasoning)
+			b.WriteString("\n\n</details>\n\n")
+		}
+		b.WriteString(msg.Content)
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,142 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type chatGPTConversation struct {
+	Title       string                 `json:"title"`
+	CreateTime  float64                `json:"create_time"`
+	UpdateTime  float64                `json:"update_time"`
+	CurrentNode string                 `json:"current_node"`
+	Mapping     map[string]chatGPTNode `json:"mapping"`
+}
+
+type chatGPTNode struct {
+	ID       string          `json:"id"`
+	Parent   string          `json:"parent"`
+	Children []string        `json:"children"`
+	Message  *chatGPTMessage `json:"message"`
+}
+
+type chatGPTMessage struct {
+	Author struct {
+		Role string `json:"role"`
+	} `json:"author"`
+	CreateTime float64 `json:"create_time"`
+	Content    struct {
+		ContentType string            `json:"content_type"`
+		Parts       []json.RawMessage `json:"parts"`
+		Text        string            `json:"text"`
+	} `json:"content"`
+	Metadata struct {
+		ModelSlug string `json:"model_slug"`
+	} `json:"metadata"`
+}
+
+func parseChatGPT(data []byte) ([]Conversation, error) {
+	var raw []chatGPTConversation
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid conversations.json: %w", err)
+	}
+
+	convs := make([]Conversation, 0, len(raw))
+	for _, item := range raw {
+		conv := Conversation{
+			Title:     item.Title,
+			CreatedAt: unixSeconds(item.CreateTime),
+			UpdatedAt: unixSeconds(item.UpdateTime),
+		}
+		for _, node := range item.activeBranch() {
+			if node.Message == nil {
+				continue
+			}
+			role, ok := normalizeRole(node.Message.Author.Role)
+			if !ok {
+				continue
+			}
+			content := node.Message.text()
+			if content == "" {
+				continue
+			}
+			msg := Message{
+				Role:      role,
+				Content:   content,
+				CreatedAt: unixSeconds(node.Message.CreateTime),
+			}
+			if role == "assistant" {
+				msg.ModelID = node.Message.Metadata.ModelSlug
+			}
+			conv.Messages = append(conv.Messages, msg)
+		}
+		convs = append(convs, conv)
+	}
+	return convs, nil
+}
+
+func (c *chatGPTConversation) activeBranch() []chatGPTNode {
+	nodeID := c.CurrentNode
+	if nodeID == "" {
+		nodeID = c.lastLeaf()
+	}
+
+	var branch []chatGPTNode
+	visited := make(map[string]bool)
+	for nodeID != "" && !visited[nodeID] {
+		visited[nodeID] = true
+		node, ok := c.Mapping[nodeID]
+		if !ok {
+			break
+		}
+		branch = append(branch, node)
+		nodeID = node.Parent
+	}
+
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch
+}
+
+func (c *chatGPTConversation) lastLeaf() string {
+	var (
+		leafID   string
+		leafTime float64
+	)
+	for id, node := range c.Mapping {
+		if len(node.Children) > 0 || node.Message == nil {
+			continue
+		}
+		if leafID == "" || node.Message.CreateTime > leafTime {
+			leafID, leafTime = id, node.Message.CreateTime
+		}
+	}
+	return leafID
+}
+
+func (m *chatGPTMessage) text() string {
+	if len(m.Content.Parts) == 0 {
+		return strings.TrimSpace(m.Content.Text)
+	}
+
+	var parts []string
+	for _, raw := range m.Content.Parts {
+		var part string
+		if err := json.Unmarshal(raw, &part); err == nil && strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+func unixSeconds(ts float64) time.Time {
+	if ts <= 0 {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func chatGPTTestNode(id, parent string, createTime float64, children ...string) chatGPTNode {
+	node := chatGPTNode{ID: id, Parent: parent, Children: children}
+	if createTime > 0 {
+		node.Message = &chatGPTMessage{CreateTime: createTime}
+	}
+	return node
+}
+
+func TestActiveBranch(t *testing.T) {
+	regenerated := map[string]chatGPTNode{
+		"root": chatGPTTestNode("root", "", 0, "q1"),
+		"q1":   chatGPTTestNode("q1", "root", 1, "a1", "a1b"),
+		"a1":   chatGPTTestNode("a1", "q1", 2),
+		"a1b":  chatGPTTestNode("a1b", "q1", 3, "q2"),
+		"q2":   chatGPTTestNode("q2", "a1b", 4),
+	}
+
+	tests := []struct {
+		name        string
+		currentNode string
+		mapping     map[string]chatGPTNode
+		want        []string
+	}{
+		{
+			name:        "follows current node and skips abandoned regenerations",
+			currentNode: "q2",
+			mapping:     regenerated,
+			want:        []string{"root", "q1", "a1b", "q2"},
+		},
+		{
+			name:        "current node on an older branch",
+			currentNode: "a1",
+			mapping:     regenerated,
+			want:        []string{"root", "q1", "a1"},
+		},
+		{
+			name:    "falls back to the latest leaf",
+			mapping: regenerated,
+			want:    []string{"root", "q1", "a1b", "q2"},
+		},
+		{
+			name:        "stops at a missing parent",
+			currentNode: "b",
+			mapping: map[string]chatGPTNode{
+				"b": chatGPTTestNode("b", "missing", 2),
+			},
+			want: []string{"b"},
+		},
+		{
+			name:        "terminates on a parent cycle",
+			currentNode: "x",
+			mapping: map[string]chatGPTNode{
+				"x": chatGPTTestNode("x", "y", 1),
+				"y": chatGPTTestNode("y", "x", 2),
+			},
+			want: []string{"y", "x"},
+		},
+		{
+			name:        "unknown current node",
+			currentNode: "nope",
+			mapping:     regenerated,
+			want:        nil,
+		},
+		{
+			name: "empty mapping",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := &chatGPTConversation{CurrentNode: tt.currentNode, Mapping: tt.mapping}
+			var got []string
+			for _, node := range conv.activeBranch() {
+				got = append(got, node.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("activeBranch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	FormatAuto    = "auto"
+	FormatNative  = "native"
+	FormatChatGPT = "chatgpt"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported import format")
+	ErrEmptyImport       = errors.New("no conversations found in import file")
+)
+
+type Message struct {
+	Role      string
+	Content   string
+	Reasoning string
+	ModelID   string
+	CreatedAt time.Time
+}
+
+type Conversation struct {
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Messages  []Message
+}
+
+func Parse(data []byte, format string) ([]Conversation, error) {
+	if format == "" || format == FormatAuto {
+		format = detectFormat(data)
+	}
+
+	var (
+		convs []Conversation
+		err   error
+	)
+	switch format {
+	case FormatNative:
+		convs, err = parseNative(data)
+	case FormatChatGPT:
+		convs, err = parseChatGPT(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(convs) == 0 {
+		return nil, ErrEmptyImport
+	}
+	return convs, nil
+}
+
+func detectFormat(data []byte) string {
+	var probe []map[string]json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' && json.Unmarshal(trimmed, &probe) == nil && len(probe) > 0 {
+		if _, ok := probe[0]["mapping"]; ok {
+			return FormatChatGPT
+		}
+	}
+	return FormatNative
+}
+
+func normalizeRole(role string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "user", "human":
+		return "user", true
+	case "assistant", "ai", "bot", "model":
+		return "assistant", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,49 @@
+package importer
+
+import (
+	"ai-qa-backend/internal/pkg/exporter"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+func parseNative(data []byte) ([]Conversation, error) {
+	var docs []exporter.Document
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &docs); err != nil {
+			return nil, fmt.Errorf("invalid export file: %w", err)
+		}
+	} else {
+		var doc exporter.Document
+		if err := json.Unmarshal(trimmed, &doc); err != nil {
+			return nil, fmt.Errorf("invalid export file: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	convs := make([]Conversation, 0, len(docs))
+	for _, doc := range docs {
+		conv := Conversation{
+			Title:     doc.Title,
+			CreatedAt: doc.CreatedAt,
+			UpdatedAt: doc.UpdatedAt,
+		}
+		for _, msg := range doc.Messages {
+			role, ok := normalizeRole(msg.Role)
+			if !ok || msg.Content == "" {
+				continue
+			}
+			conv.Messages = append(conv.Messages, Message{
+				Role:      role,
+				Content:   msg.Content,
+				Reasoning: msg.Reasoning,
+				ModelID:   msg.ModelID,
+				CreatedAt: msg.CreatedAt,
+			})
+		}
+		convs = append(convs, conv)
+	}
+	return convs, nil
+}
@@ -8,9 +8,9 @@ import (
 )
 
 type CustomClaims struct {
-	UserID   uint   `json:"user_id"`
-	Username string `json:"username"`
-	UserTier string `json:"user_tier"`
+	UserID    uint   `json:"user_id"`
+	Username  string `json:"username"`
+	SessionID uint   `json:"sid"`
 	jwt.RegisteredClaims
 }
 
@@ -31,15 +31,16 @@ func NewJWT(secretKey string) *JWT {
 	}
 }
 
-func (j *JWT) GenerateToken(userID uint, username, userTier string, expiration time.Duration) (string, error) {
+func (j *JWT) GenerateToken(userID uint, username string, sessionID uint, tokenID string, expiresAt time.Time) (string, error) {
 	claims := CustomClaims{
-		UserID:   userID,
-		Username: username,
-		UserTier: userTier,
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			Issuer:    "ai-qa-system",
 		},
 	}
@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+type PasswordReset struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type Notifier interface {
+	SendPasswordReset(msg PasswordReset) error
+}
+
+func New(driver, filePath string) (Notifier, error) {
+	switch driver {
+	case "", DriverLog:
+		return &logNotifier{}, nil
+	case DriverFile:
+		if filePath == "" {
+			return nil, fmt.Errorf("notifier file path is required for driver %q", driver)
+		}
+		return &fileNotifier{path: filePath}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier driver %q", driver)
+	}
+}
+
+type logNotifier struct{}
+
+func (n *logNotifier) SendPasswordReset(msg PasswordReset) error {
+	log.Printf("[Notifier] Password reset token for user '%s' (id %d): %s (expires at %s)",
+		msg.Username, msg.UserID, msg.Token, msg.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (n *fileNotifier) SendPasswordReset(msg PasswordReset) error {
+	line, err := json.Marshal(struct {
+		Type string `json:"type"`
+		PasswordReset
+		SentAt time.Time `json:"sent_at"`
+	}{Type: "password_reset", PasswordReset: msg, SentAt: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(n.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,50 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Cursor struct {
+	Time time.Time
+	ID   uint
+	Key  string
+}
+
+func (c *Cursor) Encode() string {
+	raw := fmt.Sprintf("%d:%d", c.Time.UnixNano(), c.ID)
+	if c.Key != "" {
+		raw += ":" + c.Key
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) < 2 {
+		return nil, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &Cursor{Time: time.Unix(0, nanos), ID: uint(id)}
+	if len(parts) == 3 {
+		cursor.Key = parts[2]
+	}
+	return cursor, nil
+}
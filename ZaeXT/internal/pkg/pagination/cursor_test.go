@@ -0,0 +1,58 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"without key", Cursor{Time: time.Unix(1700000000, 123456789), ID: 42}},
+		{"with key", Cursor{Time: time.Unix(1700000000, 0), ID: 7, Key: "updated_at_desc"}},
+		{"key containing separator", Cursor{Time: time.Unix(0, 1), ID: 1, Key: "a:b"}},
+		{"zero time", Cursor{Time: time.Unix(0, 0), ID: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if !decoded.Time.Equal(tt.cursor.Time) || decoded.ID != tt.cursor.ID || decoded.Key != tt.cursor.Key {
+				t.Errorf("DecodeCursor() = %+v, want %+v", decoded, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"not base64", "!!!"},
+		{"missing id", encode("1700000000")},
+		{"non-numeric time", encode("abc:1")},
+		{"non-numeric id", encode("1700000000:abc")},
+		{"negative id", encode("1700000000:-1")},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.input); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", tt.input, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+var ErrWeakPassword = errors.New("密码强度不足")
+
+const MaxBytes = 72
+
+type Policy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+func (p Policy) Validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("%w: 至少需要 %d 个字符", ErrWeakPassword, p.MinLength)
+	}
+	if len(password) > MaxBytes {
+		return fmt.Errorf("%w: 不能超过 %d 字节", ErrWeakPassword, MaxBytes)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return fmt.Errorf("%w: 需要包含大写字母", ErrWeakPassword)
+	case p.RequireLower && !hasLower:
+		return fmt.Errorf("%w: 需要包含小写字母", ErrWeakPassword)
+	case p.RequireDigit && !hasDigit:
+		return fmt.Errorf("%w: 需要包含数字", ErrWeakPassword)
+	case p.RequireSymbol && !hasSymbol:
+		return fmt.Errorf("%w: 需要包含特殊符号", ErrWeakPassword)
+	}
+	return nil
+}
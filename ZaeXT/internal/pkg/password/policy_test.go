@@ -0,0 +1,43 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	strict := Policy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		password string
+		wantErr  bool
+	}{
+		{name: "meets every rule", policy: strict, password: "Passw0rd!"},
+		{name: "too short", policy: strict, password: "Pa0!", wantErr: true},
+		{name: "missing upper case", policy: strict, password: "passw0rd!", wantErr: true},
+		{name: "missing lower case", policy: strict, password: "PASSW0RD!", wantErr: true},
+		{name: "missing digit", policy: strict, password: "Password!", wantErr: true},
+		{name: "missing symbol", policy: strict, password: "Passw0rdd", wantErr: true},
+		{name: "length counts characters not bytes", policy: Policy{MinLength: 4}, password: "密码密码"},
+		{name: "exactly 72 bytes", policy: Policy{MinLength: 8}, password: strings.Repeat("a", 72)},
+		{name: "over 72 bytes", policy: Policy{MinLength: 8}, password: strings.Repeat("a", 73), wantErr: true},
+		{name: "multi-byte characters over 72 bytes", policy: Policy{MinLength: 8}, password: strings.Repeat("密", 25), wantErr: true},
+		{name: "empty policy accepts anything", policy: Policy{}, password: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password)
+			if tt.wantErr {
+				if !errors.Is(err, ErrWeakPassword) {
+					t.Errorf("Validate() error = %v, want ErrWeakPassword", err)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/pagination"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserListOptions struct {
+	Search   string
+	Tier     string
+	Role     string
+	Disabled *bool
+	After    *pagination.Cursor
+	Limit    int
+}
+
+type AuditLogListOptions struct {
+	AdminID      *uint
+	TargetUserID *uint
+	Action       string
+	After        *pagination.Cursor
+	Limit        int
+}
+
+type UserUsage struct {
+	Conversations         int64
+	ArchivedConversations int64
+	TrashedConversations  int64
+	Categories            int64
+	Messages              int64
+	RecentMessages        int64
+	LastActiveAt          *time.Time
+	ActiveSessions        int64
+}
+
+type AdminRepository interface {
+	ListUsers(opts UserListOptions) ([]*model.User, error)
+	GetUsage(userID uint, since time.Time) (*UserUsage, error)
+	UpdateUser(userID uint, updates map[string]interface{}, audit *model.AdminAuditLog) error
+	CreateAuditLog(audit *model.AdminAuditLog) error
+	GrantRole(usernames []string, role string) ([]*model.User, error)
+	ListAuditLogs(opts AuditLogListOptions) ([]*model.AdminAuditLog, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+func (r *adminRepository) ListUsers(opts UserListOptions) ([]*model.User, error) {
+	query := r.db.Model(&model.User{})
+	if opts.Search != "" {
+		query = query.Where("username LIKE ?", "%"+escapeLike(opts.Search)+"%")
+	}
+	if opts.Tier != "" {
+		query = query.Where("tier = ?", opts.Tier)
+	}
+	if opts.Role != "" {
+		query = query.Where("role = ?", opts.Role)
+	}
+	if opts.Disabled != nil {
+		if *opts.Disabled {
+			query = query.Where("disabled_at IS NOT NULL")
+		} else {
+			query = query.Where("disabled_at IS NULL")
+		}
+	}
+	if opts.After != nil {
+		query = query.Where("(created_at < ?) OR (created_at = ? AND id < ?)", opts.After.Time, opts.After.Time, opts.After.ID)
+	}
+	query = query.Order("created_at desc, id desc")
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	var users []*model.User
+	err := query.Find(&users).Error
+	return users, err
+}
+
+func (r *adminRepository) GetUsage(userID uint, since time.Time) (*UserUsage, error) {
+	usage := &UserUsage{}
+
+	if err := r.db.Model(&model.Conversation{}).Where("user_id = ?", userID).
+		Count(&usage.Conversations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&model.Conversation{}).Where("user_id = ? AND archived_at IS NOT NULL", userID).
+		Count(&usage.ArchivedConversations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Unscoped().Model(&model.Conversation{}).Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Count(&usage.TrashedConversations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&model.Category{}).Where("user_id = ?", userID).
+		Count(&usage.Categories).Error; err != nil {
+		return nil, err
+	}
+
+	userMessages := func() *gorm.DB {
+		return r.db.Table("messages").
+			Joins("JOIN conversations ON conversations.id = messages.conversation_id").
+			Where("conversations.user_id = ?", userID)
+	}
+	if err := userMessages().Count(&usage.Messages).Error; err != nil {
+		return nil, err
+	}
+	if err := userMessages().Where("messages.role = ? AND messages.created_at >= ?", "user", since).
+		Count(&usage.RecentMessages).Error; err != nil {
+		return nil, err
+	}
+	var lastActive struct{ LastActiveAt *time.Time }
+	if err := userMessages().Select("MAX(messages.created_at) AS last_active_at").Scan(&lastActive).Error; err != nil {
+		return nil, err
+	}
+	usage.LastActiveAt = lastActive.LastActiveAt
+
+	if err := r.db.Model(&model.Session{}).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Count(&usage.ActiveSessions).Error; err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (r *adminRepository) UpdateUser(userID uint, updates map[string]interface{}, audit *model.AdminAuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.User{}).Where("id = ?", userID).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&model.User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return tx.Create(audit).Error
+	})
+}
+
+func (r *adminRepository) CreateAuditLog(audit *model.AdminAuditLog) error {
+	return r.db.Create(audit).Error
+}
+
+func (r *adminRepository) GrantRole(usernames []string, role string) ([]*model.User, error) {
+	var granted []*model.User
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("username IN ? AND role <> ?", usernames, role).Find(&granted).Error; err != nil {
+			return err
+		}
+		if len(granted) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(granted))
+		logs := make([]model.AdminAuditLog, len(granted))
+		for i, user := range granted {
+			details, err := json.Marshal(map[string]string{"source": "bootstrap", "from": user.Role, "to": role})
+			if err != nil {
+				return err
+			}
+			ids[i] = user.ID
+			logs[i] = model.AdminAuditLog{
+				TargetUserID: &granted[i].ID,
+				Action:       model.AuditActionGrantRole,
+				Details:      string(details),
+			}
+		}
+		if err := tx.Model(&model.User{}).Where("id IN ?", ids).Update("role", role).Error; err != nil {
+			return err
+		}
+		return tx.Create(&logs).Error
+	})
+
+	return granted, err
+}
+
+func (r *adminRepository) ListAuditLogs(opts AuditLogListOptions) ([]*model.AdminAuditLog, error) {
+	query := r.db.Model(&model.AdminAuditLog{})
+	if opts.AdminID != nil {
+		query = query.Where("admin_id = ?", *opts.AdminID)
+	}
+	if opts.TargetUserID != nil {
+		query = query.Where("target_user_id = ?", *opts.TargetUserID)
+	}
+	if opts.Action != "" {
+		query = query.Where("action = ?", opts.Action)
+	}
+	if opts.After != nil {
+		query = query.Where("(created_at < ?) OR (created_at = ? AND id < ?)", opts.After.Time, opts.After.Time, opts.After.ID)
+	}
+	query = query.Order("created_at desc, id desc")
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	var logs []*model.AdminAuditLog
+	err := query.Find(&logs).Error
+	return logs, err
+}
@@ -2,16 +2,68 @@ package repository
 
 import (
 	"ai-qa-backend/internal/model"
+	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+const (
+	CategoryDeleteUnassign     = "unassign"
+	CategoryDeleteMoveToParent = "move_to_parent"
+	CategoryDeleteRecycle      = "recycle"
+)
+
+type CategoryDeleteResult struct {
+	Strategy      string
+	Categories    int64
+	Conversations int64
+}
+
+type CategoryRestoreResult struct {
+	Categories    int64
+	Conversations int64
+}
+
+type CategoryStat struct {
+	CategoryID     uint
+	Conversations  int64
+	Messages       int64
+	LastActivityAt *time.Time
+}
+
+type CategoryActivity struct {
+	CategoryID    *uint
+	Period        string
+	Conversations int64
+	Messages      int64
+}
+
+var activityPeriodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%x-W%v",
+	"month": "%Y-%m",
+}
+
+var ErrInvalidActivityPeriod = errors.New("invalid bucket: must be one of day, week, month")
+
 type CategoryRepository interface {
 	Create(category *model.Category) error
 	GetByID(id, userID uint) (*model.Category, error)
-	ListByUserID(userID uint) ([]*model.Category, error)
+	ListAllByUserID(userID uint) ([]*model.Category, error)
+	ListDescendantIDs(id, userID uint) ([]uint, error)
+	GetPath(id, userID uint) ([]*model.Category, error)
+	ListSiblings(userID uint, parentID *uint) ([]*model.Category, error)
+	NextPosition(userID uint, parentID *uint) (int, error)
+	UpdatePositions(userID uint, orderedIDs []uint) error
+	ListStats(userID uint) ([]*CategoryStat, error)
+	ListActivity(userID uint, bucket string, from, to time.Time) ([]*CategoryActivity, error)
 	Update(category *model.Category) error
-	DeleteByID(id, userID uint) error
+	DeleteByID(id, userID uint, strategy string, dryRun bool) (*CategoryDeleteResult, error)
+	ListDeletedByUserID(userID uint) ([]*model.Category, error)
+	RestoreByID(id, userID uint) (*CategoryRestoreResult, error)
+	PermanentDeleteByID(id, userID uint) error
+	PurgeDeletedBefore(cutoff time.Time, scope UserScope, limit int) (*PurgeBatchResult, error)
 }
 
 type categoryRepository struct {
@@ -32,44 +84,333 @@ func (r *categoryRepository) GetByID(id, userID uint) (*model.Category, error) {
 	return &category, err
 }
 
-func (r *categoryRepository) ListByUserID(userID uint) ([]*model.Category, error) {
+func (r *categoryRepository) ListAllByUserID(userID uint) ([]*model.Category, error) {
 	var categories []*model.Category
-	err := r.db.Where("user_id = ? AND parent_id IS NULL", userID).Preload("Children").Find(&categories).Error
+	err := r.db.Where("user_id = ?", userID).Order("position asc, id asc").Find(&categories).Error
 	return categories, err
 }
 
-func (r *categoryRepository) Update(category *model.Category) error {
-	return r.db.Model(category).Where("id = ? AND user_id = ?", category.ID, category.UserID).Select("Name", "ParentID").Updates(category).Error
+func (r *categoryRepository) ListSiblings(userID uint, parentID *uint) ([]*model.Category, error) {
+	var categories []*model.Category
+	query := r.db.Where("user_id = ?", userID)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	err := query.Order("position asc, id asc").Find(&categories).Error
+	return categories, err
 }
 
-func (r *categoryRepository) DeleteByID(id, userID uint) error {
+func (r *categoryRepository) NextPosition(userID uint, parentID *uint) (int, error) {
+	var maxPosition *int
+	query := r.db.Model(&model.Category{}).Where("user_id = ?", userID)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	if err := query.Select("MAX(position)").Scan(&maxPosition).Error; err != nil {
+		return 0, err
+	}
+	if maxPosition == nil {
+		return 0, nil
+	}
+	return *maxPosition + 1, nil
+}
+
+func (r *categoryRepository) UpdatePositions(userID uint, orderedIDs []uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		var idsToDelete []uint
+		for i, id := range orderedIDs {
+			if err := tx.Model(&model.Category{}).Where("id = ? AND user_id = ?", id, userID).
+				Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *categoryRepository) ListDescendantIDs(id, userID uint) ([]uint, error) {
+	return descendantIDs(r.db, id, userID)
+}
+
+func (r *categoryRepository) GetPath(id, userID uint) ([]*model.Category, error) {
+	var path []*model.Category
+
+	recursiveQuery := `
+        WITH RECURSIVE ancestors AS (
+            SELECT id, user_id, name, parent_id, created_at, updated_at, 0 AS depth FROM categories WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+            UNION ALL
+            SELECT c.id, c.user_id, c.name, c.parent_id, c.created_at, c.updated_at, a.depth + 1 FROM categories c JOIN ancestors a ON c.id = a.parent_id WHERE c.deleted_at IS NULL
+        ) SELECT id, user_id, name, parent_id, created_at, updated_at FROM ancestors ORDER BY depth DESC;
+    `
+
+	err := r.db.Raw(recursiveQuery, id, userID).Scan(&path).Error
+	return path, err
+}
+
+func (r *categoryRepository) ListStats(userID uint) ([]*CategoryStat, error) {
+	var stats []*CategoryStat
+
+	statsQuery := `
+        SELECT c.category_id AS category_id,
+               COUNT(DISTINCT c.id) AS conversations,
+               COUNT(m.id) AS messages,
+               MAX(GREATEST(c.updated_at, COALESCE(m.created_at, c.updated_at))) AS last_activity_at
+        FROM conversations c
+        LEFT JOIN messages m ON m.conversation_id = c.id
+        WHERE c.user_id = ? AND c.category_id IS NOT NULL AND c.deleted_at IS NULL AND c.is_temporary = false
+        GROUP BY c.category_id;
+    `
+
+	err := r.db.Raw(statsQuery, userID).Scan(&stats).Error
+	return stats, err
+}
+
+func (r *categoryRepository) ListActivity(userID uint, bucket string, from, to time.Time) ([]*CategoryActivity, error) {
+	format, ok := activityPeriodFormats[bucket]
+	if !ok {
+		return nil, ErrInvalidActivityPeriod
+	}
+
+	var activity []*CategoryActivity
+
+	activityQuery := `
+        SELECT t.category_id AS category_id,
+               t.period AS period,
+               COUNT(DISTINCT t.conversation_id) AS conversations,
+               COUNT(*) AS messages
+        FROM (
+            SELECT c.category_id, c.id AS conversation_id, DATE_FORMAT(m.created_at, ?) AS period
+            FROM messages m
+            JOIN conversations c ON c.id = m.conversation_id
+            WHERE c.user_id = ? AND c.deleted_at IS NULL AND c.is_temporary = false
+              AND m.created_at >= ? AND m.created_at < ?
+        ) t
+        GROUP BY t.category_id, t.period
+        ORDER BY t.period, t.category_id;
+    `
+
+	err := r.db.Raw(activityQuery, format, userID, from, to).Scan(&activity).Error
+	return activity, err
+}
+
+func (r *categoryRepository) Update(category *model.Category) error {
+	return r.db.Model(category).Where("id = ? AND user_id = ?", category.ID, category.UserID).Select("Name", "ParentID", "Position").Updates(category).Error
+}
+
+func (r *categoryRepository) DeleteByID(id, userID uint, strategy string, dryRun bool) (*CategoryDeleteResult, error) {
+	result := &CategoryDeleteResult{Strategy: strategy}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var category model.Category
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&category).Error; err != nil {
+			return err
+		}
+
+		idsToDelete, err := descendantIDs(tx, id, userID)
+		if err != nil {
+			return err
+		}
+		result.Categories = int64(len(idsToDelete))
+
+		var convIDs []uint
+		if err := tx.Model(&model.Conversation{}).Where("category_id IN ?", idsToDelete).Pluck("id", &convIDs).Error; err != nil {
+			return err
+		}
+		result.Conversations = int64(len(convIDs))
 
-		recursiveQuery := `
-            WITH RECURSIVE descendant_ids AS (
-                SELECT id FROM categories WHERE id = ? AND user_id = ?
-                UNION ALL
-                SELECT c.id FROM categories c JOIN descendant_ids d ON c.parent_id = d.id
-            ) SELECT id FROM descendant_ids;
-        `
+		if dryRun {
+			return nil
+		}
 
-		if err := tx.Raw(recursiveQuery, id, userID).Scan(&idsToDelete).Error; err != nil {
+		now := time.Now()
+		if err := tx.Unscoped().Model(&model.Conversation{}).Where("category_id IN ?", idsToDelete).
+			Update("trashed_category_id", gorm.Expr("category_id")).Error; err != nil {
+			return err
+		}
+		var newCategoryID *uint
+		if strategy == CategoryDeleteMoveToParent {
+			newCategoryID = category.ParentID
+		}
+		if err := tx.Unscoped().Model(&model.Conversation{}).Where("trashed_category_id IN ? AND category_id IN ?", idsToDelete, idsToDelete).
+			Update("category_id", newCategoryID).Error; err != nil {
 			return err
 		}
+		if strategy == CategoryDeleteRecycle && len(convIDs) > 0 {
+			if err := tx.Model(&model.Conversation{}).Where("id IN ?", convIDs).Update("deleted_at", now).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&model.Category{}).Where("id IN ?", idsToDelete).Update("deleted_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *categoryRepository) ListDeletedByUserID(userID uint) ([]*model.Category, error) {
+	var categories []*model.Category
+	err := r.db.Unscoped().Table("categories AS c").Select("c.*").
+		Joins("LEFT JOIN categories p ON p.id = c.parent_id").
+		Where("c.user_id = ? AND c.deleted_at IS NOT NULL", userID).
+		Where("p.id IS NULL OR p.deleted_at IS NULL OR p.deleted_at <> c.deleted_at").
+		Order("c.deleted_at desc, c.id desc").
+		Find(&categories).Error
+	return categories, err
+}
+
+func (r *categoryRepository) RestoreByID(id, userID uint) (*CategoryRestoreResult, error) {
+	result := &CategoryRestoreResult{}
 
-		if len(idsToDelete) == 0 {
-			return gorm.ErrRecordNotFound
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var category model.Category
+		if err := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&category).Error; err != nil {
+			return err
+		}
+
+		ids, err := trashedSubtreeIDs(tx, category)
+		if err != nil {
+			return err
+		}
+		result.Categories = int64(len(ids))
+
+		if category.ParentID != nil {
+			var liveParents int64
+			if err := tx.Model(&model.Category{}).Where("id = ? AND user_id = ?", *category.ParentID, userID).Count(&liveParents).Error; err != nil {
+				return err
+			}
+			if liveParents == 0 {
+				if err := tx.Unscoped().Model(&model.Category{}).Where("id = ?", id).Update("parent_id", nil).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.Unscoped().Model(&model.Category{}).Where("id IN ?", ids).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&model.Conversation{}).
+			Where("trashed_category_id IN ? AND deleted_at = ?", ids, category.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		reattach := tx.Unscoped().Model(&model.Conversation{}).Where("trashed_category_id IN ?", ids)
+		if category.ParentID != nil {
+			reattach = reattach.Where("category_id IS NULL OR category_id = ?", *category.ParentID)
+		} else {
+			reattach = reattach.Where("category_id IS NULL")
 		}
+		reattached := reattach.Updates(map[string]interface{}{
+			"category_id":         gorm.Expr("trashed_category_id"),
+			"trashed_category_id": nil,
+		})
+		if reattached.Error != nil {
+			return reattached.Error
+		}
+		result.Conversations = reattached.RowsAffected
+
+		return tx.Unscoped().Model(&model.Conversation{}).Where("trashed_category_id IN ?", ids).
+			Update("trashed_category_id", nil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
-		if err := tx.Model(&model.Conversation{}).Where("category_id IN ?", idsToDelete).Update("category_id", nil).Error; err != nil {
+func (r *categoryRepository) PermanentDeleteByID(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var category model.Category
+		if err := tx.Unscoped().Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&category).Error; err != nil {
+			return err
+		}
+		ids, err := trashedSubtreeIDs(tx, category)
+		if err != nil {
 			return err
 		}
+		return purgeCategories(tx, ids)
+	})
+}
+
+func (r *categoryRepository) PurgeDeletedBefore(cutoff time.Time, scope UserScope, limit int) (*PurgeBatchResult, error) {
+	result := &PurgeBatchResult{}
+	if scope.Include != nil && len(scope.Include) == 0 {
+		return result, nil
+	}
 
-		if err := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Category{}).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var roots []model.Category
+		if err := scope.apply(tx.Unscoped().Table("categories AS c").Select("c.*").
+			Joins("LEFT JOIN categories p ON p.id = c.parent_id"), "c.user_id").
+			Where("c.deleted_at < ?", cutoff).
+			Where("p.id IS NULL OR p.deleted_at IS NULL OR p.deleted_at <> c.deleted_at").
+			Order("c.id asc").Limit(limit).Find(&roots).Error; err != nil {
 			return err
 		}
+		if len(roots) == 0 {
+			return nil
+		}
 
+		var ids []uint
+		for _, root := range roots {
+			subtreeIDs, err := trashedSubtreeIDs(tx, root)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, subtreeIDs...)
+		}
+		if err := purgeCategories(tx, ids); err != nil {
+			return err
+		}
+		result.Categories = int64(len(ids))
 		return nil
 	})
+
+	return result, err
+}
+
+func purgeCategories(tx *gorm.DB, ids []uint) error {
+	if err := tx.Unscoped().Model(&model.Conversation{}).Where("trashed_category_id IN ?", ids).
+		Update("trashed_category_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Model(&model.Conversation{}).Where("category_id IN ?", ids).
+		Update("category_id", nil).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&model.Category{}).Error
+}
+
+func trashedSubtreeIDs(db *gorm.DB, root model.Category) ([]uint, error) {
+	var ids []uint
+
+	recursiveQuery := `
+        WITH RECURSIVE trashed_ids AS (
+            SELECT id FROM categories WHERE id = ? AND user_id = ?
+            UNION ALL
+            SELECT c.id FROM categories c JOIN trashed_ids t ON c.parent_id = t.id WHERE c.deleted_at = ?
+        ) SELECT id FROM trashed_ids;
+    `
+
+	err := db.Raw(recursiveQuery, root.ID, root.UserID, root.DeletedAt.Time).Scan(&ids).Error
+	return ids, err
+}
+
+func descendantIDs(db *gorm.DB, id, userID uint) ([]uint, error) {
+	var ids []uint
+
+	recursiveQuery := `
+        WITH RECURSIVE descendant_ids AS (
+            SELECT id FROM categories WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+            UNION ALL
+            SELECT c.id FROM categories c JOIN descendant_ids d ON c.parent_id = d.id WHERE c.deleted_at IS NULL
+        ) SELECT id FROM descendant_ids;
+    `
+
+	err := db.Raw(recursiveQuery, id, userID).Scan(&ids).Error
+	return ids, err
 }
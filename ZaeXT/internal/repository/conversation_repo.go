@@ -2,21 +2,80 @@ package repository
 
 import (
 	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/pagination"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+var ErrMessageNotInConversation = errors.New("message not found in conversation")
+
+const (
+	ArchivedExclude = "exclude"
+	ArchivedInclude = "include"
+	ArchivedOnly    = "only"
+)
+
+type UserScope struct {
+	Include []uint
+	Exclude []uint
+}
+
+func (s UserScope) apply(db *gorm.DB, column string) *gorm.DB {
+	if s.Include != nil {
+		db = db.Where(column+" IN ?", s.Include)
+	}
+	if len(s.Exclude) > 0 {
+		db = db.Where(column+" NOT IN ?", s.Exclude)
+	}
+	return db
+}
+
+type RecycleBinEmptyResult struct {
+	Conversations int64
+	Categories    int64
+}
+
+type ConversationListOptions struct {
+	CategoryIDs   []uint
+	Uncategorized bool
+	TagIDs        []uint
+	Search        string
+	Archived      string
+	Pinned        *bool
+	SortBy        string
+	Ascending     bool
+	After         *pagination.Cursor
+	Limit         int
+}
+
 type ConversationRepository interface {
 	Create(conv *model.Conversation) error
+	CreateWithMessages(conv *model.Conversation, messages []*model.Message) error
 	GetByID(id, userID uint) (*model.Conversation, error)
-	ListByUserID(userID uint) ([]*model.Conversation, error)
+	ListByIDs(ids []uint, userID uint) ([]*model.Conversation, error)
+	ListByUserID(userID uint, opts ConversationListOptions) ([]*model.Conversation, error)
 	Update(conv *model.Conversation) error
+	UpdateModelSettings(id uint, modelID string, enableThinking bool) error
+	SetPinnedAt(id uint, pinnedAt *time.Time) error
+	CountPinned(userID uint) (int64, error)
+	UpdateSummary(id uint, summary string) error
+	SetArchivedAt(id uint, archivedAt *time.Time) error
 	DeleteByID(id, userID uint) error
 	ListDeletedByUserID(userID uint) ([]*model.Conversation, error)
 	RestoreByID(id, userID uint) error
 	PermanentDeleteByID(id, userID uint) error
-	PermanentDeleteBefore(cutoff time.Time) (int64, error)
+	PermanentDeleteBefore(cutoff time.Time, scope UserScope, limit int) (*PurgeBatchResult, error)
+	EmptyRecycleBin(userID uint) (*RecycleBinEmptyResult, error)
+	PurgeTemporaryIdleBefore(cutoff time.Time) (int64, error)
+	Fork(id, userID uint, uptoMessageID *uint, titleSuffix string) (*model.Conversation, error)
+	MoveToCategory(ids []uint, userID uint, categoryID *uint) ([]uint, error)
+	DeleteByIDs(ids []uint, userID uint) ([]uint, error)
+	RestoreByIDs(ids []uint, userID uint) ([]uint, error)
+	PermanentDeleteByIDs(ids []uint, userID uint) ([]uint, error)
 }
 
 type conversationRepository struct {
@@ -31,15 +90,91 @@ func (r *conversationRepository) Create(conv *model.Conversation) error {
 	return r.db.Create(conv).Error
 }
 
+func (r *conversationRepository) CreateWithMessages(conv *model.Conversation, messages []*model.Message) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Messages").Create(conv).Error; err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		for _, msg := range messages {
+			msg.ConversationID = conv.ID
+		}
+		return tx.CreateInBatches(messages, 100).Error
+	})
+}
+
 func (r *conversationRepository) GetByID(id, userID uint) (*model.Conversation, error) {
 	var conv model.Conversation
-	err := r.db.Where("id = ? AND user_id = ?", id, userID).Preload("Messages").First(&conv).Error
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).
+		Preload("Messages", func(db *gorm.DB) *gorm.DB { return db.Order("created_at asc, id asc") }).
+		First(&conv).Error
 	return &conv, err
 }
 
-func (r *conversationRepository) ListByUserID(userID uint) ([]*model.Conversation, error) {
+func (r *conversationRepository) ListByIDs(ids []uint, userID uint) ([]*model.Conversation, error) {
+	var conversations []*model.Conversation
+	err := r.db.Where("id IN ? AND user_id = ?", ids, userID).Find(&conversations).Error
+	return conversations, err
+}
+
+func (r *conversationRepository) ListByUserID(userID uint, opts ConversationListOptions) ([]*model.Conversation, error) {
+	query := r.db.Where("user_id = ? AND is_temporary = ?", userID, false)
+
+	if opts.Uncategorized {
+		query = query.Where("category_id IS NULL")
+	} else if len(opts.CategoryIDs) > 0 {
+		query = query.Where("category_id IN ?", opts.CategoryIDs)
+	}
+	if len(opts.TagIDs) > 0 {
+		query = query.Where(
+			"id IN (SELECT conversation_id FROM conversation_tags WHERE tag_id IN ? GROUP BY conversation_id HAVING COUNT(DISTINCT tag_id) = ?)",
+			opts.TagIDs, len(opts.TagIDs),
+		)
+	}
+	if opts.Search != "" {
+		query = query.Where("title LIKE ?", "%"+escapeLike(opts.Search)+"%")
+	}
+	switch opts.Archived {
+	case ArchivedInclude:
+	case ArchivedOnly:
+		query = query.Where("archived_at IS NOT NULL")
+	default:
+		query = query.Where("archived_at IS NULL")
+	}
+	if opts.Pinned != nil {
+		if *opts.Pinned {
+			query = query.Where("pinned_at IS NOT NULL")
+		} else {
+			query = query.Where("pinned_at IS NULL")
+		}
+	}
+
+	sortColumn := "updated_at"
+	if opts.SortBy == "created_at" {
+		sortColumn = "created_at"
+	}
+	direction, comparator := "desc", "<"
+	if opts.Ascending {
+		direction, comparator = "asc", ">"
+	}
+	if opts.After != nil {
+		query = query.Where(
+			fmt.Sprintf("(%[1]s %[2]s ?) OR (%[1]s = ? AND id %[2]s ?)", sortColumn, comparator),
+			opts.After.Time, opts.After.Time, opts.After.ID,
+		)
+	}
+	if opts.Pinned != nil && *opts.Pinned {
+		query = query.Order("pinned_at desc")
+	}
+	query = query.Order(fmt.Sprintf("%s %s, id %s", sortColumn, direction, direction))
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+
 	var conversations []*model.Conversation
-	err := r.db.Where("user_id = ?", userID).Order("updated_at desc").Find(&conversations).Error
+	err := query.Preload("Tags").Find(&conversations).Error
 	return conversations, err
 }
 
@@ -47,6 +182,33 @@ func (r *conversationRepository) Update(conv *model.Conversation) error {
 	return r.db.Save(conv).Error
 }
 
+func (r *conversationRepository) UpdateModelSettings(id uint, modelID string, enableThinking bool) error {
+	return r.db.Model(&model.Conversation{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"model_id": modelID, "enable_thinking": enableThinking}).Error
+}
+
+func (r *conversationRepository) SetPinnedAt(id uint, pinnedAt *time.Time) error {
+	return r.db.Model(&model.Conversation{}).Where("id = ?", id).UpdateColumn("pinned_at", pinnedAt).Error
+}
+
+func (r *conversationRepository) CountPinned(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Conversation{}).Where("user_id = ? AND pinned_at IS NOT NULL", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *conversationRepository) UpdateSummary(id uint, summary string) error {
+	return r.db.Model(&model.Conversation{}).Where("id = ?", id).UpdateColumn("summary", summary).Error
+}
+
+func (r *conversationRepository) SetArchivedAt(id uint, archivedAt *time.Time) error {
+	columns := map[string]interface{}{"archived_at": archivedAt}
+	if archivedAt != nil {
+		columns["pinned_at"] = nil
+	}
+	return r.db.Model(&model.Conversation{}).Where("id = ?", id).UpdateColumns(columns).Error
+}
+
 func (r *conversationRepository) DeleteByID(id, userID uint) error {
 	return r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Conversation{}).Error
 }
@@ -64,46 +226,284 @@ func (r *conversationRepository) RestoreByID(id, userID uint) error {
 }
 
 func (r *conversationRepository) PermanentDeleteByID(id, userID uint) error {
-	tx := r.db.Begin()
-	if err := tx.Where("conversation_id = ?", id).Delete(&model.Message{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-	if err := tx.Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&model.Conversation{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-	return tx.Commit().Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var conv model.Conversation
+		if err := tx.Unscoped().Select("id").Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&conv).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("conversation_id = ?", conv.ID).Delete(&model.Message{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("conversation_id = ?", conv.ID).Delete(&model.ConversationShare{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM conversation_tags WHERE conversation_id = ?", conv.ID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&model.Conversation{}, conv.ID).Error
+	})
 }
 
-func (r *conversationRepository) PermanentDeleteBefore(cutoff time.Time) (int64, error) {
-	var conversationsToDelete []model.Conversation
-	var idsToDelete []uint
+func (r *conversationRepository) PermanentDeleteBefore(cutoff time.Time, scope UserScope, limit int) (*PurgeBatchResult, error) {
+	result := &PurgeBatchResult{}
+	if scope.Include != nil && len(scope.Include) == 0 {
+		return result, nil
+	}
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
-			Find(&conversationsToDelete).Error; err != nil {
+		var idsToDelete []uint
+		if err := scope.apply(tx.Unscoped().Model(&model.Conversation{}), "user_id").
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND archived_at IS NULL", cutoff).
+			Order("id asc").Limit(limit).Pluck("id", &idsToDelete).Error; err != nil {
 			return err
 		}
 
-		if len(conversationsToDelete) == 0 {
+		if len(idsToDelete) == 0 {
 			return nil
 		}
 
-		for _, conv := range conversationsToDelete {
-			idsToDelete = append(idsToDelete, conv.ID)
+		messages := tx.Where("conversation_id IN ?", idsToDelete).Delete(&model.Message{})
+		if messages.Error != nil {
+			return messages.Error
 		}
 
-		if err := tx.Where("conversation_id IN ?", idsToDelete).Delete(&model.Message{}).Error; err != nil {
+		if err := tx.Where("conversation_id IN ?", idsToDelete).Delete(&model.ConversationShare{}).Error; err != nil {
 			return err
 		}
 
-		result := tx.Unscoped().Where("id IN ?", idsToDelete).Delete(&model.Conversation{})
-		if result.Error != nil {
-			return result.Error
+		if err := tx.Exec("DELETE FROM conversation_tags WHERE conversation_id IN ?", idsToDelete).Error; err != nil {
+			return err
 		}
+
+		if err := tx.Unscoped().Where("id IN ?", idsToDelete).Delete(&model.Conversation{}).Error; err != nil {
+			return err
+		}
+
+		result.Conversations = int64(len(idsToDelete))
+		result.Messages = messages.RowsAffected
 		return nil
 	})
 
+	return result, err
+}
+
+func (r *conversationRepository) EmptyRecycleBin(userID uint) (*RecycleBinEmptyResult, error) {
+	result := &RecycleBinEmptyResult{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var convIDs []uint
+		if err := tx.Unscoped().Model(&model.Conversation{}).
+			Where("user_id = ? AND deleted_at IS NOT NULL", userID).Pluck("id", &convIDs).Error; err != nil {
+			return err
+		}
+		if len(convIDs) > 0 {
+			if err := tx.Where("conversation_id IN ?", convIDs).Delete(&model.Message{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("conversation_id IN ?", convIDs).Delete(&model.ConversationShare{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM conversation_tags WHERE conversation_id IN ?", convIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("id IN ?", convIDs).Delete(&model.Conversation{}).Error; err != nil {
+				return err
+			}
+		}
+
+		var categoryIDs []uint
+		if err := tx.Unscoped().Model(&model.Category{}).
+			Where("user_id = ? AND deleted_at IS NOT NULL", userID).Pluck("id", &categoryIDs).Error; err != nil {
+			return err
+		}
+		if len(categoryIDs) > 0 {
+			if err := purgeCategories(tx, categoryIDs); err != nil {
+				return err
+			}
+		}
+
+		result.Conversations = int64(len(convIDs))
+		result.Categories = int64(len(categoryIDs))
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *conversationRepository) PurgeTemporaryIdleBefore(cutoff time.Time) (int64, error) {
+	var idsToDelete []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&model.Conversation{}).
+			Where("is_temporary = ? AND updated_at < ? AND archived_at IS NULL", true, cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM messages m WHERE m.conversation_id = conversations.id AND m.created_at >= ?)", cutoff).
+			Pluck("id", &idsToDelete).Error; err != nil {
+			return err
+		}
+
+		if len(idsToDelete) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("conversation_id IN ?", idsToDelete).Delete(&model.Message{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM conversation_tags WHERE conversation_id IN ?", idsToDelete).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("id IN ?", idsToDelete).Delete(&model.Conversation{}).Error
+	})
+
 	return int64(len(idsToDelete)), err
 }
+
+func (r *conversationRepository) Fork(id, userID uint, uptoMessageID *uint, titleSuffix string) (*model.Conversation, error) {
+	var forked *model.Conversation
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var source model.Conversation
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&source).Error; err != nil {
+			return err
+		}
+
+		var messages []*model.Message
+		if err := tx.Where("conversation_id = ?", id).Order("created_at asc, id asc").Find(&messages).Error; err != nil {
+			return err
+		}
+
+		if uptoMessageID != nil {
+			cut := -1
+			for i, msg := range messages {
+				if msg.ID == *uptoMessageID {
+					cut = i
+					break
+				}
+			}
+			if cut < 0 {
+				return ErrMessageNotInConversation
+			}
+			messages = messages[:cut+1]
+		}
+
+		title := []rune(source.Title + titleSuffix)
+		if len(title) > 255 {
+			title = title[:255]
+		}
+		forked = &model.Conversation{
+			UserID:              userID,
+			Title:               string(title),
+			IsTitleUserModified: true,
+			CategoryID:          source.CategoryID,
+			IsTemporary:         source.IsTemporary,
+			ModelID:             source.ModelID,
+			EnableThinking:      source.EnableThinking,
+		}
+		if err := tx.Omit("Messages").Create(forked).Error; err != nil {
+			return err
+		}
+
+		if len(messages) == 0 {
+			return nil
+		}
+		copies := make([]*model.Message, len(messages))
+		for i, msg := range messages {
+			copies[i] = &model.Message{
+				BaseModel:        model.BaseModel{CreatedAt: msg.CreatedAt, UpdatedAt: msg.UpdatedAt},
+				ConversationID:   forked.ID,
+				Role:             msg.Role,
+				Content:          msg.Content,
+				ReasoningContent: msg.ReasoningContent,
+				ModelID:          msg.ModelID,
+				EnableThinking:   msg.EnableThinking,
+			}
+		}
+		return tx.CreateInBatches(copies, 100).Error
+	})
+
+	return forked, err
+}
+
+func (r *conversationRepository) MoveToCategory(ids []uint, userID uint, categoryID *uint) ([]uint, error) {
+	var affected []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Conversation{}).Where("id IN ? AND user_id = ?", ids, userID).
+			Pluck("id", &affected).Error; err != nil {
+			return err
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		return tx.Model(&model.Conversation{}).Where("id IN ?", affected).Update("category_id", categoryID).Error
+	})
+
+	return affected, err
+}
+
+func (r *conversationRepository) DeleteByIDs(ids []uint, userID uint) ([]uint, error) {
+	var affected []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Conversation{}).Where("id IN ? AND user_id = ?", ids, userID).
+			Pluck("id", &affected).Error; err != nil {
+			return err
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		return tx.Where("id IN ?", affected).Delete(&model.Conversation{}).Error
+	})
+
+	return affected, err
+}
+
+func (r *conversationRepository) RestoreByIDs(ids []uint, userID uint) ([]uint, error) {
+	var affected []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&model.Conversation{}).
+			Where("id IN ? AND user_id = ? AND deleted_at IS NOT NULL", ids, userID).
+			Pluck("id", &affected).Error; err != nil {
+			return err
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		return tx.Unscoped().Model(&model.Conversation{}).Where("id IN ?", affected).Update("deleted_at", nil).Error
+	})
+
+	return affected, err
+}
+
+func (r *conversationRepository) PermanentDeleteByIDs(ids []uint, userID uint) ([]uint, error) {
+	var affected []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&model.Conversation{}).
+			Where("id IN ? AND user_id = ? AND deleted_at IS NOT NULL", ids, userID).
+			Pluck("id", &affected).Error; err != nil {
+			return err
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		if err := tx.Where("conversation_id IN ?", affected).Delete(&model.Message{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("conversation_id IN ?", affected).Delete(&model.ConversationShare{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM conversation_tags WHERE conversation_id IN ?", affected).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", affected).Delete(&model.Conversation{}).Error
+	})
+
+	return affected, err
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
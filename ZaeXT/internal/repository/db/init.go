@@ -60,6 +60,15 @@ func Init() (*gorm.DB, error) {
 		&model.Conversation{},
 		&model.Message{},
 		&model.Category{},
+		&model.Job{},
+		&model.ConversationShare{},
+		&model.Tag{},
+		&model.Task{},
+		&model.PurgeRun{},
+		&model.Session{},
+		&model.RevokedToken{},
+		&model.AdminAuditLog{},
+		&model.PasswordResetToken{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("database auto migrate failed: %w", err)
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type JobRepository interface {
+	Create(job *model.Job) error
+	GetByID(id, userID uint) (*model.Job, error)
+	Update(job *model.Job) error
+	Heartbeat(id uint, at time.Time) error
+	FailUnfinished(owner string, heartbeatBefore time.Time, reason string) (int64, error)
+	CountUnfinished(userID uint, jobType string) (int64, error)
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) Create(job *model.Job) error {
+	return r.db.Create(job).Error
+}
+
+func (r *jobRepository) GetByID(id, userID uint) (*model.Job, error) {
+	var job model.Job
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&job).Error
+	return &job, err
+}
+
+func (r *jobRepository) Update(job *model.Job) error {
+	return r.db.Save(job).Error
+}
+
+func (r *jobRepository) Heartbeat(id uint, at time.Time) error {
+	return r.db.Model(&model.Job{}).
+		Where("id = ? AND status IN ?", id, []string{model.JobStatusPending, model.JobStatusRunning}).
+		UpdateColumn("heartbeat_at", at).Error
+}
+
+func (r *jobRepository) FailUnfinished(owner string, heartbeatBefore time.Time, reason string) (int64, error) {
+	orphaned := r.db.Where("heartbeat_at IS NULL OR heartbeat_at < ?", heartbeatBefore)
+	if owner != "" {
+		orphaned = orphaned.Or("owner = ?", owner)
+	}
+	result := r.db.Model(&model.Job{}).
+		Where("status IN ?", []string{model.JobStatusPending, model.JobStatusRunning}).
+		Where(orphaned).
+		Updates(map[string]interface{}{
+			"status":      model.JobStatusFailed,
+			"error":       reason,
+			"finished_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *jobRepository) CountUnfinished(userID uint, jobType string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Job{}).
+		Where("user_id = ? AND type = ? AND status IN ?", userID, jobType, []string{model.JobStatusPending, model.JobStatusRunning}).
+		Count(&count).Error
+	return count, err
+}
@@ -37,6 +37,6 @@ func (r *messageRepository) CreateBatch(messages []*model.Message) error {
 
 func (r *messageRepository) GetByConversationID(convID uint) ([]*model.Message, error) {
 	var messages []*model.Message
-	err := r.db.Where("conversation_id = ?", convID).Order("created_at asc").Find(&messages).Error
+	err := r.db.Where("conversation_id = ?", convID).Order("created_at asc, id asc").Find(&messages).Error
 	return messages, err
 }
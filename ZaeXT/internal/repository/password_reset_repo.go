@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PasswordResetRepository interface {
+	Create(resetToken *model.PasswordResetToken) error
+	CountRecent(userID uint, since time.Time) (int64, error)
+	ResetPassword(tokenHash, passwordHash string, now time.Time) (uint, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Create(resetToken *model.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", resetToken.UserID).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(resetToken).Error
+	})
+}
+
+func (r *passwordResetRepository) CountRecent(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.PasswordResetToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *passwordResetRepository) ResetPassword(tokenHash, passwordHash string, now time.Time) (uint, error) {
+	var resetToken model.PasswordResetToken
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&resetToken).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.User{}).Where("id = ?", resetToken.UserID).Updates(map[string]interface{}{
+			"password_hash":           passwordHash,
+			"password_reset_required": false,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.PasswordResetToken{}).Where("user_id = ? AND used_at IS NULL", resetToken.UserID).
+			Update("used_at", now).Error
+	})
+
+	return resetToken.UserID, err
+}
+
+func (r *passwordResetRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&model.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
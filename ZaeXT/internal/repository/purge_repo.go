@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+type PurgeBatchResult struct {
+	Conversations int64
+	Messages      int64
+	Categories    int64
+}
+
+type PurgeRepository interface {
+	TryLock(ctx context.Context, name string) (func() error, bool, error)
+	CreateRun(run *model.PurgeRun) error
+	UpdateRun(run *model.PurgeRun) error
+}
+
+type purgeRepository struct {
+	db *gorm.DB
+}
+
+func NewPurgeRepository(db *gorm.DB) PurgeRepository {
+	return &purgeRepository{db: db}
+}
+
+func (r *purgeRepository) TryLock(ctx context.Context, name string) (func() error, bool, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return nil, false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", name)
+		return err
+	}
+	return release, true, nil
+}
+
+func (r *purgeRepository) CreateRun(run *model.PurgeRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *purgeRepository) UpdateRun(run *model.PurgeRun) error {
+	return r.db.Save(run).Error
+}
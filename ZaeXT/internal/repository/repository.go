@@ -3,17 +3,33 @@ package repository
 import "gorm.io/gorm"
 
 type Repository struct {
-	User         UserRepository
-	Conversation ConversationRepository
-	Message      MessageRepository
-	Category     CategoryRepository
+	User          UserRepository
+	Conversation  ConversationRepository
+	Message       MessageRepository
+	Category      CategoryRepository
+	Job           JobRepository
+	Share         ShareRepository
+	Tag           TagRepository
+	Task          TaskRepository
+	Purge         PurgeRepository
+	Session       SessionRepository
+	Admin         AdminRepository
+	PasswordReset PasswordResetRepository
 }
 
 func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{
-		User:         NewUserRepository(db),
-		Conversation: NewConversationRepository(db),
-		Message:      NewMessageRepository(db),
-		Category:     NewCategoryRepository(db),
+		User:          NewUserRepository(db),
+		Conversation:  NewConversationRepository(db),
+		Message:       NewMessageRepository(db),
+		Category:      NewCategoryRepository(db),
+		Job:           NewJobRepository(db),
+		Share:         NewShareRepository(db),
+		Tag:           NewTagRepository(db),
+		Task:          NewTaskRepository(db),
+		Purge:         NewPurgeRepository(db),
+		Session:       NewSessionRepository(db),
+		Admin:         NewAdminRepository(db),
+		PasswordReset: NewPasswordResetRepository(db),
 	}
 }
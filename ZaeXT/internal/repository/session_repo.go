@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SessionRepository interface {
+	Create(session *model.Session) error
+	GetByTokenHash(hash string) (*model.Session, error)
+	Rotate(session *model.Session, presentedHash string) (bool, error)
+	RevokeByID(id, userID uint, now time.Time) (int64, error)
+	RevokeByUserID(userID, exceptSessionID uint, now time.Time) (int64, error)
+	IsTokenRevoked(tokenID string, sessionID uint) (bool, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(session *model.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *sessionRepository) GetByTokenHash(hash string) (*model.Session, error) {
+	var session model.Session
+	err := r.db.Where("refresh_token_hash = ? OR previous_token_hash = ?", hash, hash).First(&session).Error
+	return &session, err
+}
+
+func (r *sessionRepository) Rotate(session *model.Session, presentedHash string) (bool, error) {
+	result := r.db.Model(&model.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, presentedHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  session.RefreshTokenHash,
+			"previous_token_hash": presentedHash,
+			"access_token_id":     session.AccessTokenID,
+			"access_expires_at":   session.AccessExpiresAt,
+			"last_used_at":        session.LastUsedAt,
+			"user_agent":          session.UserAgent,
+			"ip":                  session.IP,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *sessionRepository) RevokeByID(id, userID uint, now time.Time) (int64, error) {
+	return r.revoke(func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ? AND user_id = ?", id, userID)
+	}, now)
+}
+
+func (r *sessionRepository) RevokeByUserID(userID, exceptSessionID uint, now time.Time) (int64, error) {
+	return r.revoke(func(db *gorm.DB) *gorm.DB {
+		db = db.Where("user_id = ?", userID)
+		if exceptSessionID != 0 {
+			db = db.Where("id <> ?", exceptSessionID)
+		}
+		return db
+	}, now)
+}
+
+func (r *sessionRepository) revoke(scope func(*gorm.DB) *gorm.DB, now time.Time) (int64, error) {
+	var revoked int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var sessions []model.Session
+		if err := tx.Scopes(scope).Where("revoked_at IS NULL").Find(&sessions).Error; err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(sessions))
+		tokens := make([]model.RevokedToken, 0, len(sessions))
+		for i, session := range sessions {
+			ids[i] = session.ID
+			if session.AccessTokenID != "" && session.AccessExpiresAt.After(now) {
+				tokens = append(tokens, model.RevokedToken{
+					TokenID:   session.AccessTokenID,
+					UserID:    session.UserID,
+					ExpiresAt: session.AccessExpiresAt,
+				})
+			}
+		}
+
+		if err := tx.Model(&model.Session{}).Where("id IN ?", ids).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if len(tokens) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error; err != nil {
+				return err
+			}
+		}
+		revoked = int64(len(ids))
+		return nil
+	})
+
+	return revoked, err
+}
+
+func (r *sessionRepository) IsTokenRevoked(tokenID string, sessionID uint) (bool, error) {
+	var active int64
+	if err := r.db.Model(&model.Session{}).Where("id = ? AND revoked_at IS NULL", sessionID).Count(&active).Error; err != nil {
+		return false, err
+	}
+	if active == 0 {
+		return true, nil
+	}
+
+	var count int64
+	err := r.db.Model(&model.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *sessionRepository) DeleteExpired(now time.Time) (int64, error) {
+	var deleted int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		sessions := tx.Where("expires_at < ?", now).Delete(&model.Session{})
+		if sessions.Error != nil {
+			return sessions.Error
+		}
+		tokens := tx.Where("expires_at < ?", now).Delete(&model.RevokedToken{})
+		if tokens.Error != nil {
+			return tokens.Error
+		}
+		deleted = sessions.RowsAffected + tokens.RowsAffected
+		return nil
+	})
+
+	return deleted, err
+}
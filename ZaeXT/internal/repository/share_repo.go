@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type ShareRepository interface {
+	Create(share *model.ConversationShare) error
+	GetByID(id, userID uint) (*model.ConversationShare, error)
+	GetByToken(token string) (*model.ConversationShare, error)
+	ListByConversationID(convID, userID uint) ([]*model.ConversationShare, error)
+	UpdateFields(id uint, fields map[string]interface{}) error
+	IncrementViewCount(id uint) error
+}
+
+type shareRepository struct {
+	db *gorm.DB
+}
+
+func NewShareRepository(db *gorm.DB) ShareRepository {
+	return &shareRepository{db: db}
+}
+
+func (r *shareRepository) Create(share *model.ConversationShare) error {
+	return r.db.Create(share).Error
+}
+
+func (r *shareRepository) GetByID(id, userID uint) (*model.ConversationShare, error) {
+	var share model.ConversationShare
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&share).Error
+	return &share, err
+}
+
+func (r *shareRepository) GetByToken(token string) (*model.ConversationShare, error) {
+	var share model.ConversationShare
+	err := r.db.Where("token = ?", token).First(&share).Error
+	return &share, err
+}
+
+func (r *shareRepository) ListByConversationID(convID, userID uint) ([]*model.ConversationShare, error) {
+	var shares []*model.ConversationShare
+	err := r.db.Where("conversation_id = ? AND user_id = ?", convID, userID).Order("created_at desc").Find(&shares).Error
+	return shares, err
+}
+
+func (r *shareRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&model.ConversationShare{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *shareRepository) IncrementViewCount(id uint) error {
+	return r.db.Model(&model.ConversationShare{}).Where("id = ?", id).
+		UpdateColumn("view_count", gorm.Expr("view_count + ?", 1)).Error
+}
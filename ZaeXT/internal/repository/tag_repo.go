@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	Create(tag *model.Tag) error
+	GetByID(id, userID uint) (*model.Tag, error)
+	GetByName(userID uint, name string) (*model.Tag, error)
+	ListByUserID(userID uint) ([]*model.Tag, error)
+	ListByIDs(ids []uint, userID uint) ([]*model.Tag, error)
+	Update(tag *model.Tag) error
+	DeleteByID(id, userID uint) error
+	AddToConversation(convID uint, tags []*model.Tag) error
+	RemoveFromConversation(convID uint, tag *model.Tag) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) Create(tag *model.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *tagRepository) GetByID(id, userID uint) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&tag).Error
+	return &tag, err
+}
+
+func (r *tagRepository) GetByName(userID uint, name string) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&tag).Error
+	return &tag, err
+}
+
+func (r *tagRepository) ListByUserID(userID uint) ([]*model.Tag, error) {
+	var tags []*model.Tag
+	err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) ListByIDs(ids []uint, userID uint) ([]*model.Tag, error) {
+	var tags []*model.Tag
+	err := r.db.Where("id IN ? AND user_id = ?", ids, userID).Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) Update(tag *model.Tag) error {
+	return r.db.Model(tag).Where("id = ? AND user_id = ?", tag.ID, tag.UserID).Select("Name", "Color").Updates(tag).Error
+}
+
+func (r *tagRepository) DeleteByID(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var tag model.Tag
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&tag).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM conversation_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag).Error
+	})
+}
+
+func (r *tagRepository) AddToConversation(convID uint, tags []*model.Tag) error {
+	conv := &model.Conversation{BaseModel: model.BaseModel{ID: convID}}
+	return r.db.Model(conv).Omit("Tags.*").Association("Tags").Append(tags)
+}
+
+func (r *tagRepository) RemoveFromConversation(convID uint, tag *model.Tag) error {
+	conv := &model.Conversation{BaseModel: model.BaseModel{ID: convID}}
+	return r.db.Model(conv).Association("Tags").Delete(tag)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"ai-qa-backend/internal/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskRepository interface {
+	Create(task *model.Task) error
+	ExistsPending(conversationID uint, taskType string) (bool, error)
+	ClaimNext(workerID string, now time.Time) (*model.Task, error)
+	ListByConversationID(conversationID, userID uint) ([]*model.Task, error)
+	ListStale(lockedBefore time.Time) ([]*model.Task, error)
+	Release(task *model.Task, lockedBy string) (bool, error)
+	DeleteFinishedBefore(cutoff time.Time) (int64, error)
+}
+
+type taskRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskRepository(db *gorm.DB) TaskRepository {
+	return &taskRepository{db: db}
+}
+
+func (r *taskRepository) Create(task *model.Task) error {
+	return r.db.Create(task).Error
+}
+
+func (r *taskRepository) ExistsPending(conversationID uint, taskType string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Task{}).
+		Where("conversation_id = ? AND type = ? AND status = ?", conversationID, taskType, model.TaskStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *taskRepository) ClaimNext(workerID string, now time.Time) (*model.Task, error) {
+	var task model.Task
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", model.TaskStatusPending, now).
+			Order("run_at asc, id asc").
+			First(&task).Error
+		if err != nil {
+			return err
+		}
+
+		task.Status = model.TaskStatusRunning
+		task.Attempts++
+		task.LockedAt = &now
+		task.LockedBy = workerID
+		return tx.Model(&task).Select("Status", "Attempts", "LockedAt", "LockedBy").Updates(&task).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *taskRepository) ListByConversationID(conversationID, userID uint) ([]*model.Task, error) {
+	var tasks []*model.Task
+	err := r.db.Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Order("created_at desc, id desc").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) ListStale(lockedBefore time.Time) ([]*model.Task, error) {
+	var tasks []*model.Task
+	err := r.db.Where("status = ? AND locked_at < ?", model.TaskStatusRunning, lockedBefore).
+		Order("locked_at asc, id asc").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *taskRepository) Release(task *model.Task, lockedBy string) (bool, error) {
+	result := r.db.Model(&model.Task{}).
+		Where("id = ? AND status = ? AND locked_by = ?", task.ID, model.TaskStatusRunning, lockedBy).
+		Updates(map[string]interface{}{
+			"status":      task.Status,
+			"run_at":      task.RunAt,
+			"locked_at":   nil,
+			"locked_by":   "",
+			"last_error":  task.LastError,
+			"finished_at": task.FinishedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *taskRepository) DeleteFinishedBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("status IN ? AND finished_at < ?", []string{model.TaskStatusSucceeded, model.TaskStatusDead}, cutoff).
+		Delete(&model.Task{})
+	return result.RowsAffected, result.Error
+}
@@ -11,6 +11,10 @@ type UserRepository interface {
 	GetByUsername(username string) (*model.User, error)
 	GetByID(id uint) (*model.User, error)
 	Update(user *model.User) error
+	UpdateMemory(id uint, memoryInfo string) error
+	UpdateRetentionDays(id uint, retentionDays *int) error
+	UpdatePassword(id uint, passwordHash string) error
+	ListWithRetentionOverride() ([]*model.User, error)
 }
 
 type userRepository struct {
@@ -40,3 +44,22 @@ func (r *userRepository) GetByID(id uint) (*model.User, error) {
 func (r *userRepository) Update(user *model.User) error {
 	return r.db.Save(user).Error
 }
+
+func (r *userRepository) UpdateMemory(id uint, memoryInfo string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("memory_info", memoryInfo).Error
+}
+
+func (r *userRepository) UpdateRetentionDays(id uint, retentionDays *int) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("retention_days", retentionDays).Error
+}
+
+func (r *userRepository) UpdatePassword(id uint, passwordHash string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"password_hash": passwordHash, "password_reset_required": false}).Error
+}
+
+func (r *userRepository) ListWithRetentionOverride() ([]*model.User, error) {
+	var users []*model.User
+	err := r.db.Select("id", "tier", "retention_days").Where("retention_days IS NOT NULL").Find(&users).Error
+	return users, err
+}
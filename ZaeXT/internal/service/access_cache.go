@@ -0,0 +1,66 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+type UserAccess struct {
+	Tier                  string
+	Role                  string
+	Disabled              bool
+	PasswordResetRequired bool
+}
+
+type accessCacheEntry struct {
+	access    UserAccess
+	expiresAt time.Time
+}
+
+type accessCache struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	entries   map[uint]accessCacheEntry
+	lastSweep time.Time
+}
+
+func newAccessCache(ttl time.Duration) *accessCache {
+	return &accessCache{ttl: ttl, entries: make(map[uint]accessCacheEntry)}
+}
+
+func (c *accessCache) get(userID uint) (UserAccess, bool) {
+	if c.ttl <= 0 {
+		return UserAccess{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[userID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return UserAccess{}, false
+	}
+	return entry.access, true
+}
+
+func (c *accessCache) set(userID uint, access UserAccess) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.lastSweep) > c.ttl {
+		for id, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[userID] = accessCacheEntry{access: access, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *accessCache) invalidate(userID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, userID)
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/pagination"
+	"ai-qa-backend/internal/repository"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const usageWindow = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidTier       = errors.New("invalid tier")
+	ErrCannotModifySelf  = errors.New("admins cannot disable or reset their own account")
+	ErrAdminUserNotFound = errors.New("user not found")
+)
+
+type AdminActor struct {
+	ID uint
+	IP string
+}
+
+type AdminUserListParams struct {
+	Search   string
+	Tier     string
+	Role     string
+	Disabled *bool
+	Cursor   string
+	Limit    int
+}
+
+type AdminAuditLogListParams struct {
+	AdminID      *uint
+	TargetUserID *uint
+	Action       string
+	Cursor       string
+	Limit        int
+}
+
+type AdminService interface {
+	ListUsers(actor AdminActor, params AdminUserListParams) ([]*model.User, string, error)
+	GetUser(actor AdminActor, userID uint) (*model.User, *repository.UserUsage, error)
+	UpdateTier(actor AdminActor, userID uint, tier string) (*model.User, error)
+	SetDisabled(actor AdminActor, userID uint, disabled bool) (*model.User, error)
+	SetPasswordResetRequired(actor AdminActor, userID uint, required bool) (*model.User, error)
+	ListAuditLogs(params AdminAuditLogListParams) ([]*model.AdminAuditLog, string, error)
+	BootstrapAdmins() error
+}
+
+type adminService struct {
+	adminRepo   repository.AdminRepository
+	userRepo    repository.UserRepository
+	userService UserService
+	authService AuthService
+	aiAdapter   AIAdapter
+}
+
+func NewAdminService(adminRepo repository.AdminRepository, userRepo repository.UserRepository, userService UserService, authService AuthService, aiAdapter AIAdapter) AdminService {
+	return &adminService{
+		adminRepo:   adminRepo,
+		userRepo:    userRepo,
+		userService: userService,
+		authService: authService,
+		aiAdapter:   aiAdapter,
+	}
+}
+
+func (s *adminService) ListUsers(actor AdminActor, params AdminUserListParams) ([]*model.User, string, error) {
+	opts := repository.UserListOptions{
+		Search:   params.Search,
+		Tier:     params.Tier,
+		Role:     params.Role,
+		Disabled: params.Disabled,
+	}
+	if params.Cursor != "" {
+		after, err := pagination.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.After = after
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	opts.Limit = limit + 1
+
+	users, err := s.adminRepo.ListUsers(opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		nextCursor = (&pagination.Cursor{Time: last.CreatedAt, ID: last.ID}).Encode()
+	}
+
+	s.audit(actor, nil, model.AuditActionListUsers, map[string]interface{}{
+		"search":   params.Search,
+		"tier":     params.Tier,
+		"role":     params.Role,
+		"disabled": params.Disabled,
+	})
+	return users, nextCursor, nil
+}
+
+func (s *adminService) GetUser(actor AdminActor, userID uint) (*model.User, *repository.UserUsage, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	usage, err := s.adminRepo.GetUsage(userID, time.Now().Add(-usageWindow))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.audit(actor, &userID, model.AuditActionViewUser, nil)
+	return user, usage, nil
+}
+
+func (s *adminService) UpdateTier(actor AdminActor, userID uint, tier string) (*model.User, error) {
+	if !s.aiAdapter.IsValidTier(tier) {
+		return nil, ErrInvalidTier
+	}
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Tier == tier {
+		return user, nil
+	}
+
+	details := map[string]string{"from": user.Tier, "to": tier}
+	if err := s.update(actor, user, map[string]interface{}{"tier": tier}, model.AuditActionUpdateTier, details); err != nil {
+		return nil, err
+	}
+	user.Tier = tier
+	return user, nil
+}
+
+func (s *adminService) SetDisabled(actor AdminActor, userID uint, disabled bool) (*model.User, error) {
+	if userID == actor.ID {
+		return nil, ErrCannotModifySelf
+	}
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if (user.DisabledAt != nil) == disabled {
+		return user, nil
+	}
+
+	var disabledAt *time.Time
+	action := model.AuditActionEnableUser
+	if disabled {
+		now := time.Now()
+		disabledAt = &now
+		action = model.AuditActionDisableUser
+	}
+	if err := s.update(actor, user, map[string]interface{}{"disabled_at": disabledAt}, action, nil); err != nil {
+		return nil, err
+	}
+	user.DisabledAt = disabledAt
+
+	if disabled {
+		s.revokeSessions(user.ID)
+	}
+	return user, nil
+}
+
+func (s *adminService) SetPasswordResetRequired(actor AdminActor, userID uint, required bool) (*model.User, error) {
+	if userID == actor.ID {
+		return nil, ErrCannotModifySelf
+	}
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.PasswordResetRequired == required {
+		return user, nil
+	}
+
+	action := model.AuditActionCancelPasswordReset
+	if required {
+		action = model.AuditActionRequirePasswordReset
+	}
+	if err := s.update(actor, user, map[string]interface{}{"password_reset_required": required}, action, nil); err != nil {
+		return nil, err
+	}
+	user.PasswordResetRequired = required
+
+	if required {
+		s.revokeSessions(user.ID)
+	}
+	return user, nil
+}
+
+func (s *adminService) ListAuditLogs(params AdminAuditLogListParams) ([]*model.AdminAuditLog, string, error) {
+	opts := repository.AuditLogListOptions{
+		AdminID:      params.AdminID,
+		TargetUserID: params.TargetUserID,
+		Action:       params.Action,
+	}
+	if params.Cursor != "" {
+		after, err := pagination.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.After = after
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	opts.Limit = limit + 1
+
+	logs, err := s.adminRepo.ListAuditLogs(opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[len(logs)-1]
+		nextCursor = (&pagination.Cursor{Time: last.CreatedAt, ID: last.ID}).Encode()
+	}
+	return logs, nextCursor, nil
+}
+
+func (s *adminService) BootstrapAdmins() error {
+	usernames := configs.Conf.Admin.BootstrapUsernames
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	granted, err := s.adminRepo.GrantRole(usernames, model.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	for _, user := range granted {
+		s.userService.InvalidateAccess(user.ID)
+		log.Printf("Granted admin role to user '%s' (id %d) from bootstrap config", user.Username, user.ID)
+	}
+	return nil
+}
+
+func (s *adminService) getUser(userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAdminUserNotFound
+	}
+	return user, err
+}
+
+func (s *adminService) update(actor AdminActor, user *model.User, updates map[string]interface{}, action string, details interface{}) error {
+	audit, err := newAuditLog(actor, &user.ID, action, details)
+	if err != nil {
+		return err
+	}
+	if err := s.adminRepo.UpdateUser(user.ID, updates, audit); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAdminUserNotFound
+		}
+		return err
+	}
+	s.userService.InvalidateAccess(user.ID)
+	return nil
+}
+
+func (s *adminService) revokeSessions(userID uint) {
+	if _, err := s.authService.LogoutAll(userID); err != nil {
+		log.Printf("Failed to revoke sessions for user %d: %v", userID, err)
+	}
+}
+
+func (s *adminService) audit(actor AdminActor, targetUserID *uint, action string, details interface{}) {
+	audit, err := newAuditLog(actor, targetUserID, action, details)
+	if err == nil {
+		err = s.adminRepo.CreateAuditLog(audit)
+	}
+	if err != nil {
+		log.Printf("Failed to write admin audit log '%s' for admin %d: %v", action, actor.ID, err)
+	}
+}
+
+func newAuditLog(actor AdminActor, targetUserID *uint, action string, details interface{}) (*model.AdminAuditLog, error) {
+	audit := &model.AdminAuditLog{
+		AdminID:      &actor.ID,
+		TargetUserID: targetUserID,
+		Action:       action,
+		IP:           actor.IP,
+	}
+	if details != nil {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return nil, err
+		}
+		audit.Details = string(raw)
+	}
+	return audit, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/jwt"
+	"ai-qa-backend/internal/pkg/token"
+	"ai-qa-backend/internal/repository"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+
+	PasswordResetRequired bool
+}
+
+type SessionMeta struct {
+	UserAgent string
+	IP        string
+}
+
+type AuthService interface {
+	IssueTokens(user *model.User, meta SessionMeta) (*TokenPair, error)
+	Refresh(refreshToken string, meta SessionMeta) (*TokenPair, error)
+	Logout(userID, sessionID uint) error
+	LogoutAll(userID uint) (int64, error)
+	RevokeOtherSessions(userID, currentSessionID uint) (int64, error)
+	IsTokenRevoked(tokenID string, sessionID uint) (bool, error)
+	CleanupExpired() (int64, error)
+}
+
+type authService struct {
+	userRepo          repository.UserRepository
+	sessionRepo       repository.SessionRepository
+	jwtHelper         *jwt.JWT
+	accessExpiration  time.Duration
+	refreshExpiration time.Duration
+}
+
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository) AuthService {
+	return &authService{
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		jwtHelper:         jwt.NewJWT(configs.Conf.JWT.Secret),
+		accessExpiration:  configs.Conf.JWT.Expiration,
+		refreshExpiration: configs.Conf.JWT.RefreshExpiration,
+	}
+}
+
+func (s *authService) IssueTokens(user *model.User, meta SessionMeta) (*TokenPair, error) {
+	refreshToken, err := token.Generate(32)
+	if err != nil {
+		return nil, err
+	}
+	tokenID, err := token.Generate(16)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &model.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: token.Hash(refreshToken),
+		AccessTokenID:    tokenID,
+		AccessExpiresAt:  now.Add(s.accessExpiration),
+		ExpiresAt:        now.Add(s.refreshExpiration),
+		LastUsedAt:       now,
+		UserAgent:        truncate(meta.UserAgent, 255),
+		IP:               truncate(meta.IP, 64),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.jwtHelper.GenerateToken(user.ID, user.Username, session.ID, tokenID, session.AccessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  session.AccessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+
+		PasswordResetRequired: user.PasswordResetRequired,
+	}, nil
+}
+
+func (s *authService) Refresh(refreshToken string, meta SessionMeta) (*TokenPair, error) {
+	presentedHash := token.Hash(refreshToken)
+	session, err := s.sessionRepo.GetByTokenHash(presentedHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if session.RefreshTokenHash != presentedHash {
+		log.Printf("Refresh token reuse detected for session %d of user %d, revoking session", session.ID, session.UserID)
+		if _, err := s.sessionRepo.RevokeByID(session.ID, session.UserID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisabledAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := token.Generate(32)
+	if err != nil {
+		return nil, err
+	}
+	tokenID, err := token.Generate(16)
+	if err != nil {
+		return nil, err
+	}
+
+	session.RefreshTokenHash = token.Hash(newRefreshToken)
+	session.AccessTokenID = tokenID
+	session.AccessExpiresAt = now.Add(s.accessExpiration)
+	session.LastUsedAt = now
+	session.UserAgent = truncate(meta.UserAgent, 255)
+	session.IP = truncate(meta.IP, 64)
+
+	rotated, err := s.sessionRepo.Rotate(session, presentedHash)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	accessToken, err := s.jwtHelper.GenerateToken(user.ID, user.Username, session.ID, tokenID, session.AccessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  session.AccessExpiresAt,
+		RefreshToken:     newRefreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+
+		PasswordResetRequired: user.PasswordResetRequired,
+	}, nil
+}
+
+func (s *authService) Logout(userID, sessionID uint) error {
+	_, err := s.sessionRepo.RevokeByID(sessionID, userID, time.Now())
+	return err
+}
+
+func (s *authService) LogoutAll(userID uint) (int64, error) {
+	return s.sessionRepo.RevokeByUserID(userID, 0, time.Now())
+}
+
+func (s *authService) RevokeOtherSessions(userID, currentSessionID uint) (int64, error) {
+	return s.sessionRepo.RevokeByUserID(userID, currentSessionID, time.Now())
+}
+
+func (s *authService) IsTokenRevoked(tokenID string, sessionID uint) (bool, error) {
+	return s.sessionRepo.IsTokenRevoked(tokenID, sessionID)
+}
+
+func (s *authService) CleanupExpired() (int64, error) {
+	return s.sessionRepo.DeleteExpired(time.Now())
+}
+
+func truncate(s string, maxRunes int) string {
+	if runes := []rune(s); len(runes) > maxRunes {
+		return string(runes[:maxRunes])
+	}
+	return s
+}
@@ -0,0 +1,24 @@
+package service
+
+type BatchItemResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+func buildBatchResults(requested, affected []uint, failureReason string) []BatchItemResult {
+	affectedSet := make(map[uint]bool, len(affected))
+	for _, id := range affected {
+		affectedSet[id] = true
+	}
+
+	ids := uniqueIDs(requested)
+	results := make([]BatchItemResult, len(ids))
+	for i, id := range ids {
+		results[i] = BatchItemResult{ID: id, Success: affectedSet[id]}
+		if !results[i].Success {
+			results[i].Error = failureReason
+		}
+	}
+	return results
+}
@@ -1,15 +1,47 @@
 package service
 
 import (
+	"ai-qa-backend/internal/configs"
 	"ai-qa-backend/internal/model"
 	"ai-qa-backend/internal/repository"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCategoryCycle   = errors.New("invalid parent: a category cannot be moved under itself or one of its descendants")
+	ErrCategoryTooDeep = errors.New("invalid parent: category tree would exceed the maximum depth")
+	ErrInvalidOrder    = errors.New("invalid order: ids must list every sibling under the parent exactly once")
+
+	ErrInvalidDeleteStrategy = errors.New("invalid strategy: must be one of unassign, move_to_parent, recycle")
+	ErrInvalidTimeRange      = errors.New("invalid time range: from must be before to")
 )
 
 type CategoryService interface {
 	Create(userID uint, name string, parentID *uint) (*model.Category, error)
-	List(userID uint) ([]*model.Category, error)
+	List(userID uint, maxDepth int, withStats bool) ([]*CategoryNode, error)
+	Activity(userID uint, bucket string, from, to *time.Time) ([]*repository.CategoryActivity, error)
 	Update(id, userID uint, name string, parentID *uint) error
-	Delete(id, userID uint) error
+	SetPosition(id, userID uint, position int) error
+	ReorderSiblings(userID uint, parentID *uint, orderedIDs []uint) error
+	Delete(id, userID uint, strategy string, preview bool) (*repository.CategoryDeleteResult, error)
+}
+
+type CategoryNode struct {
+	*model.Category
+	Path     []string
+	Depth    int
+	Stats    *CategoryStats
+	Children []*CategoryNode
+}
+
+type CategoryStats struct {
+	DirectConversations int64
+	TotalConversations  int64
+	Messages            int64
+	LastActivityAt      *time.Time
 }
 
 type categoryService struct {
@@ -21,29 +53,311 @@ func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryServ
 }
 
 func (s *categoryService) Create(userID uint, name string, parentID *uint) (*model.Category, error) {
+	if parentID != nil {
+		categories, err := s.categoryRepo.ListAllByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateCategoryParent(categories, 0, parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	position, err := s.categoryRepo.NextPosition(userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+
 	category := &model.Category{
 		UserID:   userID,
 		Name:     name,
 		ParentID: parentID,
+		Position: position,
 	}
-	err := s.categoryRepo.Create(category)
+	err = s.categoryRepo.Create(category)
 	return category, err
 }
 
-func (s *categoryService) List(userID uint) ([]*model.Category, error) {
-	return s.categoryRepo.ListByUserID(userID)
+func (s *categoryService) List(userID uint, maxDepth int, withStats bool) ([]*CategoryNode, error) {
+	categories, err := s.categoryRepo.ListAllByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	tree := buildCategoryTree(categories, maxDepth)
+	if !withStats {
+		return tree, nil
+	}
+
+	stats, err := s.categoryRepo.ListStats(userID)
+	if err != nil {
+		return nil, err
+	}
+	attachCategoryStats(tree, aggregateCategoryStats(categories, stats))
+	return tree, nil
+}
+
+func (s *categoryService) Activity(userID uint, bucket string, from, to *time.Time) ([]*repository.CategoryActivity, error) {
+	if bucket == "" {
+		bucket = "day"
+	}
+	end := time.Now()
+	if to != nil {
+		end = to.AddDate(0, 0, 1)
+	}
+	start := end.AddDate(0, 0, -30)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		return nil, ErrInvalidTimeRange
+	}
+	return s.categoryRepo.ListActivity(userID, bucket, start, end)
 }
 
 func (s *categoryService) Update(id, userID uint, name string, parentID *uint) error {
 	category, err := s.categoryRepo.GetByID(id, userID)
 	if err != nil {
-		return err
+		return errors.New("category not found or permission denied")
+	}
+	if parentID != nil {
+		categories, err := s.categoryRepo.ListAllByUserID(userID)
+		if err != nil {
+			return err
+		}
+		if err := validateCategoryParent(categories, id, parentID); err != nil {
+			return err
+		}
+	}
+	if !equalParent(category.ParentID, parentID) {
+		position, err := s.categoryRepo.NextPosition(userID, parentID)
+		if err != nil {
+			return err
+		}
+		category.Position = position
 	}
 	category.Name = name
 	category.ParentID = parentID
 	return s.categoryRepo.Update(category)
 }
 
-func (s *categoryService) Delete(id, userID uint) error {
-	return s.categoryRepo.DeleteByID(id, userID)
+func (s *categoryService) SetPosition(id, userID uint, position int) error {
+	category, err := s.categoryRepo.GetByID(id, userID)
+	if err != nil {
+		return errors.New("category not found or permission denied")
+	}
+
+	siblings, err := s.categoryRepo.ListSiblings(userID, category.ParentID)
+	if err != nil {
+		return err
+	}
+
+	orderedIDs := make([]uint, 0, len(siblings))
+	for _, sibling := range siblings {
+		if sibling.ID != id {
+			orderedIDs = append(orderedIDs, sibling.ID)
+		}
+	}
+	position = min(max(position, 0), len(orderedIDs))
+	orderedIDs = append(orderedIDs[:position], append([]uint{id}, orderedIDs[position:]...)...)
+
+	return s.categoryRepo.UpdatePositions(userID, orderedIDs)
+}
+
+func (s *categoryService) ReorderSiblings(userID uint, parentID *uint, orderedIDs []uint) error {
+	if parentID != nil {
+		if _, err := s.categoryRepo.GetByID(*parentID, userID); err != nil {
+			return errors.New("parent category not found or permission denied")
+		}
+	}
+
+	siblings, err := s.categoryRepo.ListSiblings(userID, parentID)
+	if err != nil {
+		return err
+	}
+	if len(uniqueIDs(orderedIDs)) != len(orderedIDs) || len(orderedIDs) != len(siblings) {
+		return ErrInvalidOrder
+	}
+	siblingSet := make(map[uint]bool, len(siblings))
+	for _, sibling := range siblings {
+		siblingSet[sibling.ID] = true
+	}
+	for _, id := range orderedIDs {
+		if !siblingSet[id] {
+			return ErrInvalidOrder
+		}
+	}
+
+	return s.categoryRepo.UpdatePositions(userID, orderedIDs)
+}
+
+func (s *categoryService) Delete(id, userID uint, strategy string, preview bool) (*repository.CategoryDeleteResult, error) {
+	switch strategy {
+	case "":
+		strategy = repository.CategoryDeleteUnassign
+	case repository.CategoryDeleteUnassign, repository.CategoryDeleteMoveToParent, repository.CategoryDeleteRecycle:
+	default:
+		return nil, ErrInvalidDeleteStrategy
+	}
+
+	result, err := s.categoryRepo.DeleteByID(id, userID, strategy, preview)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("category not found or permission denied")
+	}
+	return result, err
+}
+
+func buildCategoryTree(categories []*model.Category, maxDepth int) []*CategoryNode {
+	byID := make(map[uint]bool, len(categories))
+	for _, cat := range categories {
+		byID[cat.ID] = true
+	}
+
+	childrenOf := make(map[uint][]*model.Category)
+	var roots []*model.Category
+	for _, cat := range categories {
+		if cat.ParentID == nil || !byID[*cat.ParentID] {
+			roots = append(roots, cat)
+		} else {
+			childrenOf[*cat.ParentID] = append(childrenOf[*cat.ParentID], cat)
+		}
+	}
+
+	visited := make(map[uint]bool, len(categories))
+	var build func(cats []*model.Category, parentPath []string, depth int) []*CategoryNode
+	build = func(cats []*model.Category, parentPath []string, depth int) []*CategoryNode {
+		nodes := make([]*CategoryNode, 0, len(cats))
+		for _, cat := range cats {
+			if visited[cat.ID] {
+				continue
+			}
+			visited[cat.ID] = true
+			path := append(append(make([]string, 0, len(parentPath)+1), parentPath...), cat.Name)
+			node := &CategoryNode{Category: cat, Path: path, Depth: depth}
+			if maxDepth <= 0 || depth < maxDepth {
+				node.Children = build(childrenOf[cat.ID], path, depth+1)
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	tree := build(roots, nil, 1)
+	if maxDepth <= 0 {
+		for _, cat := range categories {
+			if !visited[cat.ID] {
+				tree = append(tree, build([]*model.Category{cat}, nil, 1)...)
+			}
+		}
+	}
+	return tree
+}
+
+func validateCategoryParent(categories []*model.Category, id uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		return ErrCategoryCycle
+	}
+
+	byID := make(map[uint]*model.Category, len(categories))
+	childrenOf := make(map[uint][]uint)
+	for _, cat := range categories {
+		byID[cat.ID] = cat
+		if cat.ParentID != nil {
+			childrenOf[*cat.ParentID] = append(childrenOf[*cat.ParentID], cat.ID)
+		}
+	}
+	if _, ok := byID[*parentID]; !ok {
+		return errors.New("parent category not found or permission denied")
+	}
+
+	parentDepth := 0
+	visited := make(map[uint]bool)
+	for ancestorID := parentID; ancestorID != nil && !visited[*ancestorID]; {
+		if id != 0 && *ancestorID == id {
+			return ErrCategoryCycle
+		}
+		ancestor, ok := byID[*ancestorID]
+		if !ok {
+			break
+		}
+		visited[ancestor.ID] = true
+		parentDepth++
+		ancestorID = ancestor.ParentID
+	}
+
+	subtreeHeight := 1
+	if id != 0 {
+		subtreeHeight = 0
+		level := []uint{id}
+		seen := map[uint]bool{id: true}
+		for len(level) > 0 {
+			subtreeHeight++
+			var next []uint
+			for _, nodeID := range level {
+				for _, childID := range childrenOf[nodeID] {
+					if !seen[childID] {
+						seen[childID] = true
+						next = append(next, childID)
+					}
+				}
+			}
+			level = next
+		}
+	}
+
+	if maxDepth := configs.Conf.Category.MaxDepth; maxDepth > 0 && parentDepth+subtreeHeight > maxDepth {
+		return ErrCategoryTooDeep
+	}
+	return nil
+}
+
+func aggregateCategoryStats(categories []*model.Category, direct []*repository.CategoryStat) map[uint]*CategoryStats {
+	directByID := make(map[uint]*repository.CategoryStat, len(direct))
+	for _, stat := range direct {
+		directByID[stat.CategoryID] = stat
+	}
+	childrenOf := make(map[uint][]uint)
+	for _, cat := range categories {
+		if cat.ParentID != nil {
+			childrenOf[*cat.ParentID] = append(childrenOf[*cat.ParentID], cat.ID)
+		}
+	}
+
+	result := make(map[uint]*CategoryStats, len(categories))
+	var aggregate func(id uint) *CategoryStats
+	aggregate = func(id uint) *CategoryStats {
+		if stats, ok := result[id]; ok {
+			return stats
+		}
+		stats := &CategoryStats{}
+		result[id] = stats
+		if stat, ok := directByID[id]; ok {
+			stats.DirectConversations = stat.Conversations
+			stats.TotalConversations = stat.Conversations
+			stats.Messages = stat.Messages
+			stats.LastActivityAt = stat.LastActivityAt
+		}
+		for _, childID := range childrenOf[id] {
+			child := aggregate(childID)
+			stats.TotalConversations += child.TotalConversations
+			stats.Messages += child.Messages
+			if child.LastActivityAt != nil && (stats.LastActivityAt == nil || child.LastActivityAt.After(*stats.LastActivityAt)) {
+				stats.LastActivityAt = child.LastActivityAt
+			}
+		}
+		return stats
+	}
+	for _, cat := range categories {
+		aggregate(cat.ID)
+	}
+	return result
+}
+
+func attachCategoryStats(nodes []*CategoryNode, stats map[uint]*CategoryStats) {
+	for _, node := range nodes {
+		node.Stats = stats[node.ID]
+		attachCategoryStats(node.Children, stats)
+	}
 }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/repository"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCategory(id uint, name string, parentID uint) *model.Category {
+	cat := &model.Category{BaseModel: model.BaseModel{ID: id}, Name: name}
+	if parentID != 0 {
+		cat.ParentID = &parentID
+	}
+	return cat
+}
+
+func flattenCategoryTree(nodes []*CategoryNode) []string {
+	var lines []string
+	for _, node := range nodes {
+		lines = append(lines, fmt.Sprintf("%d:%s", node.Depth, strings.Join(node.Path, "/")))
+		lines = append(lines, flattenCategoryTree(node.Children)...)
+	}
+	return lines
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	categories := []*model.Category{
+		testCategory(1, "Work", 0),
+		testCategory(2, "Go", 1),
+		testCategory(3, "Gin", 2),
+		testCategory(4, "Life", 0),
+		testCategory(5, "Rust", 1),
+	}
+
+	tests := []struct {
+		name       string
+		categories []*model.Category
+		maxDepth   int
+		want       []string
+	}{
+		{
+			name:       "full tree keeps sibling order",
+			categories: categories,
+			want:       []string{"1:Work", "2:Work/Go", "3:Work/Go/Gin", "2:Work/Rust", "1:Life"},
+		},
+		{
+			name:       "max depth truncates deeper levels",
+			categories: categories,
+			maxDepth:   2,
+			want:       []string{"1:Work", "2:Work/Go", "2:Work/Rust", "1:Life"},
+		},
+		{
+			name:       "max depth of one returns only roots",
+			categories: categories,
+			maxDepth:   1,
+			want:       []string{"1:Work", "1:Life"},
+		},
+		{
+			name: "category with a missing parent becomes a root",
+			categories: []*model.Category{
+				testCategory(1, "Orphan", 99),
+				testCategory(2, "Child", 1),
+			},
+			want: []string{"1:Orphan", "2:Orphan/Child"},
+		},
+		{
+			name: "categories in a parent cycle are still listed once",
+			categories: []*model.Category{
+				testCategory(1, "Root", 0),
+				testCategory(2, "A", 3),
+				testCategory(3, "B", 2),
+			},
+			want: []string{"1:Root", "1:A", "2:A/B"},
+		},
+		{
+			name: "empty input",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenCategoryTree(buildCategoryTree(tt.categories, tt.maxDepth))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildCategoryTree() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCategoryParent(t *testing.T) {
+	previous := configs.Conf
+	t.Cleanup(func() { configs.Conf = previous })
+
+	categories := []*model.Category{
+		testCategory(1, "Work", 0),
+		testCategory(2, "Go", 1),
+		testCategory(3, "Gin", 2),
+		testCategory(4, "Life", 0),
+		testCategory(5, "Tools", 4),
+	}
+	parent := func(id uint) *uint { return &id }
+
+	tests := []struct {
+		name         string
+		id           uint
+		parentID     *uint
+		maxDepth     int
+		wantErr      error
+		wantNotFound bool
+	}{
+		{name: "no parent", id: 2, maxDepth: 3},
+		{name: "new category under a root", parentID: parent(1), maxDepth: 3},
+		{name: "new category at the depth limit", parentID: parent(2), maxDepth: 3},
+		{name: "new category beyond the depth limit", parentID: parent(3), maxDepth: 3, wantErr: ErrCategoryTooDeep},
+		{name: "unlimited depth", parentID: parent(3), maxDepth: 0},
+		{name: "parent not owned by the user", parentID: parent(99), maxDepth: 3, wantNotFound: true},
+		{name: "move under itself", id: 1, parentID: parent(1), maxDepth: 3, wantErr: ErrCategoryCycle},
+		{name: "move under its own child", id: 1, parentID: parent(2), maxDepth: 3, wantErr: ErrCategoryCycle},
+		{name: "move under a deeper descendant", id: 1, parentID: parent(3), maxDepth: 0, wantErr: ErrCategoryCycle},
+		{name: "move subtree that still fits", id: 2, parentID: parent(4), maxDepth: 3},
+		{name: "move subtree that would exceed the limit", id: 2, parentID: parent(5), maxDepth: 3, wantErr: ErrCategoryTooDeep},
+		{name: "move leaf to a deeper parent", id: 3, parentID: parent(5), maxDepth: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs.Conf = &configs.Config{Category: configs.CategoryConfig{MaxDepth: tt.maxDepth}}
+			err := validateCategoryParent(categories, tt.id, tt.parentID)
+			switch {
+			case tt.wantNotFound:
+				if err == nil || !strings.Contains(err.Error(), "permission denied") {
+					t.Errorf("validateCategoryParent() error = %v, want not found", err)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Errorf("validateCategoryParent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAggregateCategoryStats(t *testing.T) {
+	categories := []*model.Category{
+		testCategory(1, "Work", 0),
+		testCategory(2, "Go", 1),
+		testCategory(3, "Gin", 2),
+		testCategory(4, "Life", 0),
+	}
+	day := func(d int) *time.Time {
+		at := time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+		return &at
+	}
+	direct := []*repository.CategoryStat{
+		{CategoryID: 1, Conversations: 2, Messages: 10, LastActivityAt: day(1)},
+		{CategoryID: 3, Conversations: 1, Messages: 4, LastActivityAt: day(5)},
+		{CategoryID: 99, Conversations: 7, Messages: 70, LastActivityAt: day(9)},
+	}
+
+	tests := []struct {
+		id   uint
+		want CategoryStats
+	}{
+		{id: 1, want: CategoryStats{DirectConversations: 2, TotalConversations: 3, Messages: 14, LastActivityAt: day(5)}},
+		{id: 2, want: CategoryStats{DirectConversations: 0, TotalConversations: 1, Messages: 4, LastActivityAt: day(5)}},
+		{id: 3, want: CategoryStats{DirectConversations: 1, TotalConversations: 1, Messages: 4, LastActivityAt: day(5)}},
+		{id: 4, want: CategoryStats{}},
+	}
+
+	stats := aggregateCategoryStats(categories, direct)
+	if len(stats) != len(categories) {
+		t.Fatalf("aggregateCategoryStats() returned %d entries, want %d", len(stats), len(categories))
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("category %d", tt.id), func(t *testing.T) {
+			got := stats[tt.id]
+			if got == nil {
+				t.Fatalf("missing stats for category %d", tt.id)
+			}
+			sameTime := (got.LastActivityAt == nil) == (tt.want.LastActivityAt == nil) &&
+				(got.LastActivityAt == nil || got.LastActivityAt.Equal(*tt.want.LastActivityAt))
+			if got.DirectConversations != tt.want.DirectConversations || got.TotalConversations != tt.want.TotalConversations ||
+				got.Messages != tt.want.Messages || !sameTime {
+				t.Errorf("stats = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	tree := buildCategoryTree(categories, 0)
+	attachCategoryStats(tree, stats)
+	if tree[0].Stats != stats[1] || tree[0].Children[0].Children[0].Stats != stats[3] {
+		t.Errorf("attachCategoryStats() did not attach stats to nested nodes")
+	}
+}
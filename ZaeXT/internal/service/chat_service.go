@@ -2,31 +2,78 @@ package service
 
 import (
 	"ai-qa-backend/internal/adapter/volcengine"
+	"ai-qa-backend/internal/configs"
 	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/pagination"
 	"ai-qa-backend/internal/repository"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type AIAdapter interface {
 	ChatStream(req volcengine.ChatRequest, userTier, modelID string, enableThinking bool) (<-chan []byte, <-chan error)
 	GetAvailableModelsForTier(userTier string) []volcengine.AvailableModel
+	IsValidModelForTier(modelID, userTier string) bool
+	IsValidTier(tier string) bool
+}
+
+var ErrTooManyJobs = errors.New("too many auto-classify jobs in progress")
+
+const maxPinnedConversations = 50
+
+type CategoryProposal struct {
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type ClassificationResult struct {
+	CategoryID       *uint             `json:"category_id"`
+	ProposedCategory *CategoryProposal `json:"proposed_category,omitempty"`
+	Confidence       float64           `json:"confidence"`
+	SuggestedTagIDs  []uint            `json:"suggested_tag_ids"`
+}
+
+type ListConversationsParams struct {
+	CategoryID         *uint
+	IncludeDescendants bool
+	Uncategorized      bool
+	TagIDs             []uint
+	Search             string
+	Archived           string
+	SortBy             string
+	Order              string
+	Cursor             string
+	Limit              int
 }
 
 type ChatService interface {
 	CreateConversation(userID uint, isTemporary bool, categoryID *uint) (*model.Conversation, error)
 	GetConversation(convID, userID uint) (*model.Conversation, error)
-	ListConversations(userID uint) ([]*model.Conversation, error)
-	ProcessUserMessage(convID, userID uint, userTier, message, modelID string, enableThinking bool) (<-chan []byte, <-chan error)
+	ListConversations(userID uint, params ListConversationsParams) ([]*model.Conversation, string, error)
+	ProcessUserMessage(convID, userID uint, userTier, message, modelID string, enableThinking *bool) (<-chan []byte, <-chan error)
 	ListAvailableModels(userTier string) []volcengine.AvailableModel
 	UpdateConversationTitle(convID, userID uint, title string) error
 	DeleteConversation(convID, userID uint) error
-	AutoClassify(convID, userID uint) error
+	AutoClassify(convID, userID uint) (*ClassificationResult, error)
+	StartAutoClassify(userID uint, convIDs []uint, dryRun bool) (*model.Job, error)
+	ConfirmProposedCategory(convID, userID uint, proposal CategoryProposal) (*model.Category, error)
 	GetMessagesByConversationID(convID, userID uint) ([]*model.Message, error)
 	UpdateConversationCategory(convID, userID uint, newCategoryID *uint) error
+	EndTemporaryConversation(convID, userID uint) error
+	ForkConversation(convID, userID uint, messageID *uint) (*model.Conversation, error)
+	SetConversationPinned(convID, userID uint, pinned bool) error
+	SetConversationArchived(convID, userID uint, archived bool) error
+	BatchMoveConversations(convIDs []uint, userID uint, categoryID *uint) ([]BatchItemResult, error)
+	BatchDeleteConversations(convIDs []uint, userID uint) ([]BatchItemResult, error)
+	CleanupIdleTemporaryConversations() (int64, error)
+	RunTask(task *model.Task) error
 }
 
 type chatService struct {
@@ -34,7 +81,13 @@ type chatService struct {
 	msgRepo      repository.MessageRepository
 	userRepo     repository.UserRepository
 	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+	jobRepo      repository.JobRepository
+	taskRepo     repository.TaskRepository
 	aiAdapter    AIAdapter
+
+	jobStartMu       sync.Mutex
+	classifyThrottle intervalThrottle
 }
 
 func NewChatService(
@@ -42,6 +95,9 @@ func NewChatService(
 	msgRepo repository.MessageRepository,
 	userRepo repository.UserRepository,
 	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	jobRepo repository.JobRepository,
+	taskRepo repository.TaskRepository,
 	aiAdapter AIAdapter,
 ) ChatService {
 	return &chatService{
@@ -49,104 +105,328 @@ func NewChatService(
 		msgRepo:      msgRepo,
 		userRepo:     userRepo,
 		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		jobRepo:      jobRepo,
+		taskRepo:     taskRepo,
 		aiAdapter:    aiAdapter,
 	}
 }
 
-func (s *chatService) AutoClassify(convID, userID uint) error {
+func (s *chatService) AutoClassify(convID, userID uint) (*ClassificationResult, error) {
 	conv, err := s.convRepo.GetByID(convID, userID)
 	if err != nil {
-		return errors.New("conversation not found or permission denied")
+		return nil, errors.New("conversation not found or permission denied")
 	}
 
-	messages, err := s.msgRepo.GetByConversationID(convID)
+	options, err := s.loadClassificationOptions(userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if len(messages) == 0 {
-		return errors.New("cannot classify an empty conversation")
+
+	classification, err := s.classifyConversation(conv, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if classification.CategoryID != nil {
+		conv.CategoryID = classification.CategoryID
+		if err := s.convRepo.Update(conv); err != nil {
+			return nil, err
+		}
+	}
+	return classification, nil
+}
+
+func (s *chatService) ConfirmProposedCategory(convID, userID uint, proposal CategoryProposal) (*model.Category, error) {
+	conv, err := s.convRepo.GetByID(convID, userID)
+	if err != nil {
+		return nil, errors.New("conversation not found or permission denied")
+	}
+	if conv.IsTemporary {
+		return nil, errors.New("cannot classify a temporary conversation")
 	}
 
-	categories, err := s.categoryRepo.ListByUserID(userID)
+	categories, err := s.categoryRepo.ListAllByUserID(userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var category *model.Category
+	for _, cat := range categories {
+		if cat.Name == proposal.Name && equalParent(cat.ParentID, proposal.ParentID) {
+			category = cat
+		}
+	}
+
+	if category == nil {
+		if err := validateCategoryParent(categories, 0, proposal.ParentID); err != nil {
+			return nil, err
+		}
+		category = &model.Category{UserID: userID, Name: proposal.Name, ParentID: proposal.ParentID}
+		if err := s.categoryRepo.Create(category); err != nil {
+			return nil, err
+		}
+	}
+
+	conv.CategoryID = &category.ID
+	if err := s.convRepo.Update(conv); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func equalParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s *chatService) StartAutoClassify(userID uint, convIDs []uint, dryRun bool) (*model.Job, error) {
+	var convs []*model.Conversation
+	var err error
+	if len(convIDs) > 0 {
+		convIDs = uniqueIDs(convIDs)
+		convs, err = s.convRepo.ListByIDs(convIDs, userID)
+	} else {
+		convs, err = s.convRepo.ListByUserID(userID, repository.ConversationListOptions{Uncategorized: true})
 	}
-	if len(categories) == 0 {
-		return errors.New("no categories available for classification")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := s.loadClassificationOptions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(convs)
+	if len(convIDs) > 0 {
+		total = len(convIDs)
+	}
+
+	s.jobStartMu.Lock()
+	defer s.jobStartMu.Unlock()
+	if limit := configs.Conf.AutoClassify.MaxConcurrentJobs; limit > 0 {
+		active, err := s.jobRepo.CountUnfinished(userID, JobTypeAutoClassify)
+		if err != nil {
+			return nil, err
+		}
+		if active >= int64(limit) {
+			return nil, ErrTooManyJobs
+		}
+	}
+	progress, err := startJobProgress(s.jobRepo, userID, JobTypeAutoClassify, total)
+	if err != nil {
+		return nil, err
+	}
+
+	job := *progress.job
+	go s.runAutoClassify(progress, convIDs, convs, options, dryRun)
+
+	return &job, nil
+}
+
+func (s *chatService) runAutoClassify(progress *jobProgress, convIDs []uint, convs []*model.Conversation, options *classificationOptions, dryRun bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("ERROR: Auto-classify job %d panicked: %v", progress.job.ID, r)
+			progress.finish(errors.New("auto-classify aborted unexpectedly"))
+		}
+	}()
+
+	convMap := make(map[uint]*model.Conversation, len(convs))
+	for _, conv := range convs {
+		convMap[conv.ID] = conv
+	}
+	if len(convIDs) == 0 {
+		for _, conv := range convs {
+			convIDs = append(convIDs, conv.ID)
+		}
+	}
+
+	progress.running()
+	for i, convID := range convIDs {
+		result := JobItemResult{Index: i, ConversationID: convID, DryRun: dryRun}
+		conv, ok := convMap[convID]
+		if !ok {
+			result.Error = "conversation not found or permission denied"
+			progress.record(result)
+			continue
+		}
+		result.Title = conv.Title
+
+		s.classifyThrottle.Wait(configs.Conf.AutoClassify.Interval)
+		classification, err := s.classifyConversation(conv, options)
+		if err != nil {
+			result.Error = err.Error()
+			progress.record(result)
+			continue
+		}
+		result.CategoryID = classification.CategoryID
+		result.ProposedCategory = classification.ProposedCategory
+		result.Confidence = classification.Confidence
+		result.SuggestedTagIDs = classification.SuggestedTagIDs
+
+		if !dryRun && classification.CategoryID != nil {
+			affected, err := s.convRepo.MoveToCategory([]uint{conv.ID}, conv.UserID, classification.CategoryID)
+			if err != nil {
+				result.Error = err.Error()
+			} else if len(affected) == 0 {
+				result.Error = "conversation not found or permission denied"
+			}
+		}
+		progress.record(result)
+	}
+	progress.finish(nil)
+	log.Printf("INFO: Auto-classify job %d finished: %d classified, %d failed", progress.job.ID, progress.job.Processed-progress.job.Failed, progress.job.Failed)
+}
+
+type classificationOptions struct {
+	categoriesJSON string
+	tagsJSON       string
+	categorySet    map[uint]bool
+	tagSet         map[uint]bool
+}
+
+func (s *chatService) loadClassificationOptions(userID uint) (*classificationOptions, error) {
+	categories, err := s.categoryRepo.ListAllByUserID(userID)
+	if err != nil {
+		return nil, err
 	}
 
 	type categoryOption struct {
+		ID   uint   `json:"id"`
+		Path string `json:"path"`
+	}
+	type tagOption struct {
 		ID   uint   `json:"id"`
 		Name string `json:"name"`
 	}
 
-	categoryOptions := make([]categoryOption, 0)
-	userCategoryMap := make(map[uint]bool)
+	options := &classificationOptions{categorySet: make(map[uint]bool), tagSet: make(map[uint]bool)}
+
+	paths := buildCategoryPaths(categories)
+	categoryOptions := make([]categoryOption, 0, len(categories))
 	for _, cat := range categories {
-		categoryOptions = append(categoryOptions, categoryOption{ID: cat.ID, Name: cat.Name})
-		userCategoryMap[cat.ID] = true
+		categoryOptions = append(categoryOptions, categoryOption{ID: cat.ID, Path: paths[cat.ID]})
+		options.categorySet[cat.ID] = true
 	}
 	categoriesJSON, _ := json.Marshal(categoryOptions)
+	options.categoriesJSON = string(categoriesJSON)
 
-	var conversationContext strings.Builder
-	for _, msg := range messages {
-		conversationContext.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
+	tags, err := s.tagRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	tagOptions := make([]tagOption, 0, len(tags))
+	for _, tag := range tags {
+		tagOptions = append(tagOptions, tagOption{ID: tag.ID, Name: tag.Name})
+		options.tagSet[tag.ID] = true
 	}
+	tagsJSON, _ := json.Marshal(tagOptions)
+	options.tagsJSON = string(tagsJSON)
 
-	systemPrompt := `你是一个对话分类助手。你的任务是根据下面提供的对话内容，从给定的分类列表中选择一个最匹配的分类。
-你必须遵循以下规则：
-1. 仔细阅读对话内容和分类列表。
-2. 你的回答必须是一个JSON对象。
-3. JSON对象中必须只包含一个键 "category_id"，其值是你选择的分类的数字ID。
-例如: {"category_id": 123}`
-	userContent := fmt.Sprintf("=== 分类列表 ===\n%s\n\n=== 对话内容 ===\n%s", string(categoriesJSON), conversationContext.String())
+	return options, nil
+}
 
-	req := volcengine.ChatRequest{
-		SystemPrompt: systemPrompt,
-		Messages:     []*model.Message{{Role: "user", Content: userContent}},
-	}
-	freeModels := s.aiAdapter.GetAvailableModelsForTier("free")
-	if len(freeModels) == 0 {
-		return errors.New("no 'free' models configured for auto-classification")
+func buildCategoryPaths(categories []*model.Category) map[uint]string {
+	byID := make(map[uint]*model.Category, len(categories))
+	for _, cat := range categories {
+		byID[cat.ID] = cat
 	}
-	modelID := freeModels[0].ID
-
-	resChan, errChan := s.aiAdapter.ChatStream(req, "free", modelID, false)
 
-	var jsonAccumulator strings.Builder
-	for chunk := range resChan {
-		var streamResp struct {
-			Choices []struct {
-				Delta struct {
-					Content string `json:"content"`
-				} `json:"delta"`
-			} `json:"choices"`
-		}
-		if err := json.Unmarshal(chunk, &streamResp); err == nil {
-			if len(streamResp.Choices) > 0 {
-				jsonAccumulator.WriteString(streamResp.Choices[0].Delta.Content)
+	paths := make(map[uint]string, len(categories))
+	for _, cat := range categories {
+		names := []string{cat.Name}
+		visited := map[uint]bool{cat.ID: true}
+		for parentID := cat.ParentID; parentID != nil && !visited[*parentID]; {
+			parent, ok := byID[*parentID]
+			if !ok {
+				break
 			}
+			visited[parent.ID] = true
+			names = append([]string{parent.Name}, names...)
+			parentID = parent.ParentID
 		}
+		paths[cat.ID] = strings.Join(names, " / ")
+	}
+	return paths
+}
+
+func (s *chatService) classifyConversation(conv *model.Conversation, options *classificationOptions) (*ClassificationResult, error) {
+	if conv.IsTemporary {
+		return nil, errors.New("cannot classify a temporary conversation")
 	}
 
-	if err := <-errChan; err != nil {
-		return fmt.Errorf("ai call failed: %w", err)
+	messages, err := s.msgRepo.GetByConversationID(conv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, errors.New("cannot classify an empty conversation")
+	}
+
+	var conversationContext strings.Builder
+	for _, msg := range messages {
+		conversationContext.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
 	}
 
-	responseStr := jsonAccumulator.String()
+	systemPrompt := `你是一个对话分类助手。你的任务是根据下面提供的对话内容，从给定的分类列表中选择一个最匹配的分类。
+你必须遵循以下规则：
+1. 仔细阅读对话内容和分类列表。分类列表包含所有层级的分类，"path" 为从顶级分类到该分类的完整路径，优先选择最具体的匹配分类。
+2. 你的回答必须是一个JSON对象。
+3. JSON对象中必须包含键 "category_id"，其值是你选择的分类的数字ID；如果没有合适的分类或分类列表为空，其值为 null。
+4. 当 "category_id" 为 null 时，必须包含键 "new_category"，其值为建议新建的分类，格式为 {"name": "分类名称", "parent_id": 父分类ID或null}；否则 "new_category" 为 null。
+5. JSON对象中必须包含键 "confidence"，其值是0到1之间的小数，表示你对该分类结果的把握程度。
+6. JSON对象中必须包含键 "tag_ids"，其值是从标签列表中选出的0到3个最相关标签的数字ID数组；标签列表为空时返回空数组。
+例如: {"category_id": 123, "new_category": null, "confidence": 0.85, "tag_ids": [4, 5]}
+或: {"category_id": null, "new_category": {"name": "旅行", "parent_id": 12}, "confidence": 0.6, "tag_ids": []}`
+	userContent := fmt.Sprintf("=== 分类列表 ===\n%s\n\n=== 标签列表 ===\n%s\n\n=== 对话内容 ===\n%s", options.categoriesJSON, options.tagsJSON, conversationContext.String())
+
+	responseStr, err := s.completeWithFreeModel(systemPrompt, []*model.Message{{Role: "user", Content: userContent}})
+	if err != nil {
+		return nil, fmt.Errorf("ai call failed: %w", err)
+	}
 	var result struct {
-		CategoryID uint `json:"category_id"`
+		CategoryID  *uint             `json:"category_id"`
+		NewCategory *CategoryProposal `json:"new_category"`
+		Confidence  float64           `json:"confidence"`
+		TagIDs      []uint            `json:"tag_ids"`
 	}
 	if err := json.Unmarshal([]byte(responseStr), &result); err != nil {
-		return fmt.Errorf("failed to parse ai response json: %w (raw response: %s)", err, responseStr)
+		return nil, fmt.Errorf("failed to parse ai response json: %w (raw response: %s)", err, responseStr)
 	}
 
-	if _, ok := userCategoryMap[result.CategoryID]; !ok {
-		return errors.New("ai returned an invalid or unauthorized category id")
+	classification := &ClassificationResult{
+		Confidence:      min(max(result.Confidence, 0), 1),
+		SuggestedTagIDs: make([]uint, 0, len(result.TagIDs)),
+	}
+	switch {
+	case result.CategoryID != nil:
+		if !options.categorySet[*result.CategoryID] {
+			return nil, errors.New("ai returned an invalid or unauthorized category id")
+		}
+		classification.CategoryID = result.CategoryID
+	case result.NewCategory != nil:
+		proposal := result.NewCategory
+		proposal.Name = strings.TrimSpace(proposal.Name)
+		if proposal.Name == "" || len([]rune(proposal.Name)) > 100 {
+			return nil, errors.New("ai proposed an invalid category name")
+		}
+		if proposal.ParentID != nil && !options.categorySet[*proposal.ParentID] {
+			proposal.ParentID = nil
+		}
+		classification.ProposedCategory = proposal
+	default:
+		return nil, errors.New("ai returned neither a category nor a new category proposal")
+	}
+	for _, tagID := range uniqueIDs(result.TagIDs) {
+		if options.tagSet[tagID] {
+			classification.SuggestedTagIDs = append(classification.SuggestedTagIDs, tagID)
+		}
 	}
 
-	conv.CategoryID = &result.CategoryID
-	return s.convRepo.Update(conv)
+	return classification, nil
 }
 
 func (s *chatService) UpdateConversationCategory(convID, userID uint, newCategoryID *uint) error {
@@ -192,8 +472,103 @@ func (s *chatService) GetConversation(convID, userID uint) (*model.Conversation,
 	return conv, err
 }
 
-func (s *chatService) ListConversations(userID uint) ([]*model.Conversation, error) {
-	return s.convRepo.ListByUserID(userID)
+func (s *chatService) ListConversations(userID uint, params ListConversationsParams) ([]*model.Conversation, string, error) {
+	opts := repository.ConversationListOptions{
+		Uncategorized: params.Uncategorized,
+		Search:        strings.TrimSpace(params.Search),
+		Archived:      params.Archived,
+		SortBy:        params.SortBy,
+		Ascending:     params.Order == "asc",
+	}
+	if opts.Search != "" && opts.Archived == "" {
+		opts.Archived = repository.ArchivedInclude
+	}
+	sortKey := "updated_at_desc"
+	if opts.SortBy == "created_at" {
+		sortKey = "created_at_desc"
+	}
+	if opts.Ascending {
+		sortKey = strings.TrimSuffix(sortKey, "desc") + "asc"
+	}
+
+	if len(params.TagIDs) > 0 {
+		tagIDs := uniqueIDs(params.TagIDs)
+		tags, err := s.tagRepo.ListByIDs(tagIDs, userID)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(tags) != len(tagIDs) {
+			return nil, "", errors.New("tag not found or permission denied")
+		}
+		opts.TagIDs = tagIDs
+	}
+
+	if params.CategoryID != nil && !params.Uncategorized {
+		if params.IncludeDescendants {
+			ids, err := s.categoryRepo.ListDescendantIDs(*params.CategoryID, userID)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(ids) == 0 {
+				return nil, "", errors.New("target category not found or permission denied")
+			}
+			opts.CategoryIDs = ids
+		} else {
+			if _, err := s.categoryRepo.GetByID(*params.CategoryID, userID); err != nil {
+				return nil, "", errors.New("target category not found or permission denied")
+			}
+			opts.CategoryIDs = []uint{*params.CategoryID}
+		}
+	}
+
+	pinnedOnly, unpinnedOnly := true, false
+
+	var pinned []*model.Conversation
+	if params.Cursor == "" {
+		pinnedOpts := opts
+		pinnedOpts.Pinned = &pinnedOnly
+		pinnedOpts.Limit = maxPinnedConversations
+		var err error
+		pinned, err = s.convRepo.ListByUserID(userID, pinnedOpts)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		after, err := pagination.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if after.Key != sortKey {
+			return nil, "", pagination.ErrInvalidCursor
+		}
+		opts.After = after
+	}
+
+	opts.Pinned = &unpinnedOnly
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, 100)
+	opts.Limit = limit + 1
+
+	convs, err := s.convRepo.ListByUserID(userID, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(convs) > limit {
+		convs = convs[:limit]
+		last := convs[len(convs)-1]
+		cursor := pagination.Cursor{Time: last.UpdatedAt, ID: last.ID, Key: sortKey}
+		if params.SortBy == "created_at" {
+			cursor.Time = last.CreatedAt
+		}
+		nextCursor = cursor.Encode()
+	}
+
+	return append(pinned, convs...), nextCursor, nil
 }
 
 func (s *chatService) GetMessagesByConversationID(convID, userID uint) ([]*model.Message, error) {
@@ -204,7 +579,7 @@ func (s *chatService) GetMessagesByConversationID(convID, userID uint) ([]*model
 	return s.msgRepo.GetByConversationID(convID)
 }
 
-func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message, modelID string, enableThinking bool) (<-chan []byte, <-chan error) {
+func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message, modelID string, enableThinking *bool) (<-chan []byte, <-chan error) {
 	conv, err := s.convRepo.GetByID(convID, userID)
 	if err != nil {
 		errChan := make(chan error, 1)
@@ -219,6 +594,25 @@ func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message,
 		close(errChan)
 		return nil, errChan
 	}
+	modelID, thinking, err := s.resolveModelSettings(conv, userTier, modelID, enableThinking)
+	if err != nil {
+		errChan := make(chan error, 1)
+		errChan <- err
+		close(errChan)
+		return nil, errChan
+	}
+	if modelID != conv.ModelID || thinking != conv.EnableThinking {
+		if err := s.convRepo.UpdateModelSettings(conv.ID, modelID, thinking); err != nil {
+			errChan := make(chan error, 1)
+			errChan <- err
+			close(errChan)
+			return nil, errChan
+		}
+		if conv.ModelID != "" {
+			log.Printf("INFO: Conversation %d switched model settings to %s (thinking=%t)", conv.ID, modelID, thinking)
+		}
+		conv.ModelID, conv.EnableThinking = modelID, thinking
+	}
 	userMsg := &model.Message{ConversationID: convID, Role: "user", Content: message}
 	if err := s.msgRepo.Create(userMsg); err != nil {
 		errChan := make(chan error, 1)
@@ -233,16 +627,6 @@ func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message,
 		close(errChan)
 		return nil, errChan
 	}
-	if modelID == "" {
-		availableModels := s.aiAdapter.GetAvailableModelsForTier(userTier)
-		if len(availableModels) == 0 {
-			errChan := make(chan error, 1)
-			errChan <- errors.New("no available models for your tier")
-			close(errChan)
-			return nil, errChan
-		}
-		modelID = availableModels[0].ID
-	}
 
 	handlerResponseChan := make(chan []byte)
 	handlerErrChan := make(chan error, 1)
@@ -253,9 +637,10 @@ func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message,
 
 		systemPrompt := fmt.Sprintf("这是关于 '%s' 的对话。请记住以下用户信息：%s", conv.Title, user.MemoryInfo)
 		aiReq := volcengine.ChatRequest{SystemPrompt: systemPrompt, Messages: history}
-		adapterResponseChan, adapterErrChan := s.aiAdapter.ChatStream(aiReq, userTier, modelID, enableThinking)
+		adapterResponseChan, adapterErrChan := s.aiAdapter.ChatStream(aiReq, userTier, modelID, thinking)
 
 		var dbContentAccumulator strings.Builder
+		var reasoningAccumulator strings.Builder
 		var streamErr error
 
 		for {
@@ -268,13 +653,15 @@ func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message,
 					var streamResp struct {
 						Choices []struct {
 							Delta struct {
-								Content string `json:"content"`
+								Content          string `json:"content"`
+								ReasoningContent string `json:"reasoning_content"`
 							} `json:"delta"`
 						} `json:"choices"`
 					}
 					if err := json.Unmarshal(chunk, &streamResp); err == nil {
 						if len(streamResp.Choices) > 0 {
 							dbContentAccumulator.WriteString(streamResp.Choices[0].Delta.Content)
+							reasoningAccumulator.WriteString(streamResp.Choices[0].Delta.ReasoningContent)
 						}
 					}
 				}
@@ -292,26 +679,52 @@ func (s *chatService) ProcessUserMessage(convID, userID uint, userTier, message,
 		}
 		if streamErr == nil && dbContentAccumulator.Len() > 0 {
 			assistantMsg := &model.Message{
-				ConversationID: conv.ID,
-				Role:           "assistant",
-				Content:        dbContentAccumulator.String(),
+				ConversationID:   conv.ID,
+				Role:             "assistant",
+				Content:          dbContentAccumulator.String(),
+				ReasoningContent: reasoningAccumulator.String(),
+				ModelID:          modelID,
+				EnableThinking:   thinking,
 			}
 			if err := s.msgRepo.Create(assistantMsg); err != nil {
 				log.Printf("ERROR: Failed to save assistant message for conv %d: %v", conv.ID, err)
 				handlerErrChan <- err
 			}
 
-			if !conv.IsTitleUserModified {
-				log.Printf("INFO: Triggering auto title generation for conv %d", conv.ID)
-				fullHistory := append(history, assistantMsg)
-				go s.autoGenerateTitle(conv, fullHistory)
-			}
+			s.enqueueFollowUpTasks(conv)
 		}
 
 	}()
 	return handlerResponseChan, handlerErrChan
 }
 
+func (s *chatService) resolveModelSettings(conv *model.Conversation, userTier, modelID string, enableThinking *bool) (string, bool, error) {
+	thinking := conv.EnableThinking
+	if enableThinking != nil {
+		thinking = *enableThinking
+	}
+
+	switch {
+	case modelID != "":
+		if !s.aiAdapter.IsValidModelForTier(modelID, userTier) {
+			return "", false, errors.New("permission denied for the selected model")
+		}
+	case conv.ModelID != "":
+		if !s.aiAdapter.IsValidModelForTier(conv.ModelID, userTier) {
+			return "", false, fmt.Errorf("permission denied: model %s used by this conversation is no longer available for your tier, please choose another model", conv.ModelID)
+		}
+		modelID = conv.ModelID
+	default:
+		availableModels := s.aiAdapter.GetAvailableModelsForTier(userTier)
+		if len(availableModels) == 0 {
+			return "", false, errors.New("no available models for your tier")
+		}
+		modelID = availableModels[0].ID
+	}
+
+	return modelID, thinking, nil
+}
+
 func (s *chatService) UpdateConversationTitle(convID, userID uint, title string) error {
 	conv, err := s.GetConversation(convID, userID)
 	if err != nil {
@@ -330,7 +743,7 @@ func (s *chatService) UpdateConversationTitle(convID, userID uint, title string)
 			return err
 		}
 
-		go s.autoGenerateTitle(conv, history)
+		enqueueConversationTask(s.taskRepo, conv, TaskTypeGenerateTitle)
 
 		return nil
 
@@ -350,31 +763,302 @@ func (s *chatService) DeleteConversation(convID, userID uint) error {
 	return s.convRepo.DeleteByID(convID, userID)
 }
 
-func (s *chatService) autoGenerateTitle(conv *model.Conversation, history []*model.Message) {
-	log.Printf("INFO: Starting auto title generation for conv %d", conv.ID)
-	if len(history) == 0 {
+func (s *chatService) EndTemporaryConversation(convID, userID uint) error {
+	conv, err := s.convRepo.GetByID(convID, userID)
+	if err != nil {
+		return errors.New("conversation not found or permission denied")
+	}
+	if !conv.IsTemporary {
+		return errors.New("only temporary conversations can be ended")
+	}
+
+	return s.convRepo.PermanentDeleteByID(convID, userID)
+}
+
+func (s *chatService) ForkConversation(convID, userID uint, messageID *uint) (*model.Conversation, error) {
+	conv, err := s.convRepo.Fork(convID, userID, messageID, " (fork)")
+	if err != nil {
+		if errors.Is(err, repository.ErrMessageNotInConversation) {
+			return nil, err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("conversation not found or permission denied")
+		}
+		return nil, err
+	}
+	return conv, nil
+}
+
+func (s *chatService) SetConversationPinned(convID, userID uint, pinned bool) error {
+	conv, err := s.convRepo.GetByID(convID, userID)
+	if err != nil {
+		return errors.New("conversation not found or permission denied")
+	}
+
+	if !pinned {
+		if conv.PinnedAt == nil {
+			return nil
+		}
+		return s.convRepo.SetPinnedAt(conv.ID, nil)
+	}
+	if conv.PinnedAt != nil {
+		return nil
+	}
+	if conv.ArchivedAt != nil {
+		return errors.New("archived conversations cannot be pinned")
+	}
+	pinnedCount, err := s.convRepo.CountPinned(userID)
+	if err != nil {
+		return err
+	}
+	if pinnedCount >= maxPinnedConversations {
+		return fmt.Errorf("at most %d conversations can be pinned", maxPinnedConversations)
+	}
+	now := time.Now()
+	return s.convRepo.SetPinnedAt(conv.ID, &now)
+}
+
+func (s *chatService) SetConversationArchived(convID, userID uint, archived bool) error {
+	conv, err := s.convRepo.GetByID(convID, userID)
+	if err != nil {
+		return errors.New("conversation not found or permission denied")
+	}
+
+	if !archived {
+		if conv.ArchivedAt == nil {
+			return nil
+		}
+		return s.convRepo.SetArchivedAt(conv.ID, nil)
+	}
+	if conv.ArchivedAt != nil {
+		return nil
+	}
+	if conv.IsTemporary {
+		return errors.New("temporary conversations cannot be archived")
+	}
+	now := time.Now()
+	return s.convRepo.SetArchivedAt(conv.ID, &now)
+}
+
+func (s *chatService) BatchMoveConversations(convIDs []uint, userID uint, categoryID *uint) ([]BatchItemResult, error) {
+	if categoryID != nil {
+		if _, err := s.categoryRepo.GetByID(*categoryID, userID); err != nil {
+			return nil, errors.New("target category not found or permission denied")
+		}
+	}
+
+	affected, err := s.convRepo.MoveToCategory(convIDs, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return buildBatchResults(convIDs, affected, "conversation not found or permission denied"), nil
+}
+
+func (s *chatService) BatchDeleteConversations(convIDs []uint, userID uint) ([]BatchItemResult, error) {
+	affected, err := s.convRepo.DeleteByIDs(convIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildBatchResults(convIDs, affected, "conversation not found or permission denied"), nil
+}
+
+func (s *chatService) CleanupIdleTemporaryConversations() (int64, error) {
+	idleTimeout := configs.Conf.Temporary.IdleTimeout
+	if idleTimeout <= 0 {
+		return 0, nil
+	}
+
+	deletedCount, err := s.convRepo.PurgeTemporaryIdleBefore(time.Now().Add(-idleTimeout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup idle temporary conversations: %w", err)
+	}
+
+	return deletedCount, nil
+}
+
+func (s *chatService) enqueueFollowUpTasks(conv *model.Conversation) {
+	if conv.IsTemporary {
 		return
 	}
+	cfg := configs.Conf.Tasks
+	if !conv.IsTitleUserModified {
+		enqueueConversationTask(s.taskRepo, conv, TaskTypeGenerateTitle)
+	}
+	if cfg.AutoClassify && conv.CategoryID == nil {
+		enqueueConversationTask(s.taskRepo, conv, TaskTypeAutoClassify)
+	}
+	if cfg.Summarize {
+		enqueueConversationTask(s.taskRepo, conv, TaskTypeSummarize)
+	}
+	if cfg.ExtractMemory {
+		enqueueConversationTask(s.taskRepo, conv, TaskTypeExtractMemory)
+	}
+}
+
+func (s *chatService) RunTask(task *model.Task) error {
+	if task.ConversationID == nil {
+		return fmt.Errorf("task type %s requires a conversation", task.Type)
+	}
+	conv, err := s.convRepo.GetByID(*task.ConversationID, task.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("INFO: Skipping task %d, conversation %d no longer exists", task.ID, *task.ConversationID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if conv.IsTemporary {
+		return nil
+	}
+
+	switch task.Type {
+	case TaskTypeGenerateTitle:
+		return s.generateTitle(conv)
+	case TaskTypeAutoClassify:
+		return s.classifyInBackground(conv)
+	case TaskTypeSummarize:
+		return s.summarizeConversation(conv)
+	case TaskTypeExtractMemory:
+		return s.extractMemory(conv)
+	default:
+		return fmt.Errorf("unknown task type %s", task.Type)
+	}
+}
+
+func (s *chatService) generateTitle(conv *model.Conversation) error {
+	if conv.IsTitleUserModified {
+		return nil
+	}
+	history, err := s.msgRepo.GetByConversationID(conv.ID)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
 	titlePrompt := "你是一个对话标题生成助手。根据用户和助手的对话内容，生成一个简短、精确、不超过10个字的摘要作为标题。你的回答必须只包含标题本身，不要任何额外的解释、引言或标点符号。"
-	messagesForTitle := history
 	finalInstruction := &model.Message{
 		Role:    "user",
 		Content: "根据以上对话，生成一个简洁的标题。",
 	}
-	messagesForTitle = append(messagesForTitle, finalInstruction)
-	req := volcengine.ChatRequest{
-		SystemPrompt: titlePrompt,
-		Messages:     messagesForTitle,
+	reply, err := s.completeWithFreeModel(titlePrompt, append(history, finalInstruction))
+	if err != nil {
+		return fmt.Errorf("ai call for title generation failed: %w", err)
+	}
+
+	title := strings.Trim(reply, "\"“” \n\r")
+	if title == "" {
+		log.Printf("INFO: Auto-generated title is empty for conv %d", conv.ID)
+		return nil
+	}
+	if titleRunes := []rune(title); len(titleRunes) > 255 {
+		title = string(titleRunes[:255])
+	}
+
+	latestConv, err := s.convRepo.GetByID(conv.ID, conv.UserID)
+	if err != nil || latestConv.IsTitleUserModified {
+		return nil
+	}
+	latestConv.Title = title
+	if err := s.convRepo.Update(latestConv); err != nil {
+		return err
+	}
+	log.Printf("INFO: Auto-generated title '%s' for conv %d", title, conv.ID)
+	return nil
+}
+
+func (s *chatService) classifyInBackground(conv *model.Conversation) error {
+	if conv.CategoryID != nil {
+		return nil
+	}
+	options, err := s.loadClassificationOptions(conv.UserID)
+	if err != nil {
+		return err
+	}
+	classification, err := s.classifyConversation(conv, options)
+	if err != nil {
+		return err
+	}
+	if classification.CategoryID == nil {
+		log.Printf("INFO: Auto-classify proposed new category '%s' for conv %d, waiting for user confirmation", classification.ProposedCategory.Name, conv.ID)
+		return nil
 	}
+	_, err = s.convRepo.MoveToCategory([]uint{conv.ID}, conv.UserID, classification.CategoryID)
+	return err
+}
 
+func (s *chatService) summarizeConversation(conv *model.Conversation) error {
+	history, err := s.msgRepo.GetByConversationID(conv.ID)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	summaryPrompt := "你是一个对话摘要助手。请用不超过200个字概括用户与助手对话的主要内容与结论。你的回答必须只包含摘要本身，不要任何额外的解释。"
+	finalInstruction := &model.Message{
+		Role:    "user",
+		Content: "根据以上对话，生成一段摘要。",
+	}
+	summary, err := s.completeWithFreeModel(summaryPrompt, append(history, finalInstruction))
+	if err != nil {
+		return fmt.Errorf("ai call for summarization failed: %w", err)
+	}
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return nil
+	}
+
+	return s.convRepo.UpdateSummary(conv.ID, summary)
+}
+
+func (s *chatService) extractMemory(conv *model.Conversation) error {
+	user, err := s.userRepo.GetByID(conv.UserID)
+	if err != nil {
+		return err
+	}
+	history, err := s.msgRepo.GetByConversationID(conv.ID)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	memoryPrompt := "你是一个用户记忆整理助手。根据已有的用户记忆和最新的对话，提取关于用户的长期有效信息 (如身份、偏好、目标)，并与已有记忆合并成不超过500个字的要点列表。忽略一次性的问题和临时信息。如果没有新的信息，原样返回已有记忆。你的回答必须只包含记忆内容本身。"
+	var conversationContext strings.Builder
+	for _, msg := range history {
+		conversationContext.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
+	}
+	userContent := fmt.Sprintf("=== 已有记忆 ===\n%s\n\n=== 对话内容 ===\n%s", user.MemoryInfo, conversationContext.String())
+
+	memory, err := s.completeWithFreeModel(memoryPrompt, []*model.Message{{Role: "user", Content: userContent}})
+	if err != nil {
+		return fmt.Errorf("ai call for memory extraction failed: %w", err)
+	}
+	memory = strings.TrimSpace(memory)
+	if memory == "" || memory == user.MemoryInfo {
+		return nil
+	}
+
+	return s.userRepo.UpdateMemory(user.ID, memory)
+}
+
+func (s *chatService) completeWithFreeModel(systemPrompt string, messages []*model.Message) (string, error) {
 	freeModels := s.aiAdapter.GetAvailableModelsForTier("free")
 	if len(freeModels) == 0 {
-		log.Printf("ERROR: No 'free' tier models available for auto title generation.")
-		return
+		return "", errors.New("no 'free' models configured for background tasks")
 	}
-	modelID := freeModels[0].ID
-	resChan, errChan := s.aiAdapter.ChatStream(req, "free", modelID, false)
-	var titleAccumulator strings.Builder
+
+	req := volcengine.ChatRequest{
+		SystemPrompt: systemPrompt,
+		Messages:     messages,
+	}
+	resChan, errChan := s.aiAdapter.ChatStream(req, "free", freeModels[0].ID, false)
+
+	var accumulator strings.Builder
 	for chunk := range resChan {
 		var streamResp struct {
 			Choices []struct {
@@ -385,26 +1069,13 @@ func (s *chatService) autoGenerateTitle(conv *model.Conversation, history []*mod
 		}
 		if err := json.Unmarshal(chunk, &streamResp); err == nil {
 			if len(streamResp.Choices) > 0 {
-				titleAccumulator.WriteString(streamResp.Choices[0].Delta.Content)
+				accumulator.WriteString(streamResp.Choices[0].Delta.Content)
 			}
 		}
 	}
+
 	if err := <-errChan; err != nil {
-		log.Printf("ERROR: AI call for auto title generation failed for conv %d: %v", conv.ID, err)
-		return
-	}
-	title := strings.Trim(titleAccumulator.String(), "\"“” \n\r")
-	if title != "" {
-		latestConv, err := s.convRepo.GetByID(conv.ID, conv.UserID)
-		if err == nil && !latestConv.IsTitleUserModified {
-			latestConv.Title = title
-			if err := s.convRepo.Update(latestConv); err != nil {
-				log.Printf("ERROR: Failed to update auto-generated title for conv %d: %v", conv.ID, err)
-			} else {
-				log.Printf("INFO: Auto-generated title '%s' for conv %d", title, conv.ID)
-			}
-		}
-	} else {
-		log.Printf("INFO: Auto-generated title is empty for conv %d", conv.ID)
+		return "", err
 	}
+	return accumulator.String(), nil
 }
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"ai-qa-backend/internal/pkg/exporter"
+	"ai-qa-backend/internal/repository"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrConversationNotFound = errors.New("conversation not found or permission denied")
+
+type ExportedFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type ExportService interface {
+	Export(convID, userID uint, format string, includeReasoning bool) (*ExportedFile, error)
+	ExportArchive(convIDs []uint, userID uint, format string, includeReasoning bool) (func(w io.Writer) error, error)
+	BuildDocument(convID, userID uint, includeReasoning bool) (*exporter.Document, error)
+}
+
+type exportService struct {
+	convRepo     repository.ConversationRepository
+	categoryRepo repository.CategoryRepository
+}
+
+func NewExportService(
+	convRepo repository.ConversationRepository,
+	categoryRepo repository.CategoryRepository,
+) ExportService {
+	return &exportService{
+		convRepo:     convRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *exportService) Export(convID, userID uint, format string, includeReasoning bool) (*ExportedFile, error) {
+	doc, err := s.BuildDocument(convID, userID, includeReasoning)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := exporter.Render(doc, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportedFile{
+		FileName:    exporter.FileName(doc, format),
+		ContentType: exporter.ContentType(format),
+		Content:     content,
+	}, nil
+}
+
+func (s *exportService) ExportArchive(convIDs []uint, userID uint, format string, includeReasoning bool) (func(w io.Writer) error, error) {
+	if !exporter.IsSupported(format) {
+		return nil, exporter.ErrUnsupportedFormat
+	}
+
+	convs, err := s.convRepo.ListByIDs(convIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(convs) != len(uniqueIDs(convIDs)) {
+		return nil, ErrConversationNotFound
+	}
+
+	return func(w io.Writer) error {
+		zw := zip.NewWriter(w)
+		for _, conv := range convs {
+			doc, err := s.BuildDocument(conv.ID, userID, includeReasoning)
+			if err != nil {
+				return err
+			}
+			content, err := exporter.Render(doc, format)
+			if err != nil {
+				return err
+			}
+			entry, err := zw.CreateHeader(&zip.FileHeader{
+				Name:     fmt.Sprintf("%d-%s", conv.ID, exporter.FileName(doc, format)),
+				Method:   zip.Deflate,
+				Modified: conv.UpdatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := entry.Write(content); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	}, nil
+}
+
+func (s *exportService) BuildDocument(convID, userID uint, includeReasoning bool) (*exporter.Document, error) {
+	conv, err := s.convRepo.GetByID(convID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &exporter.Document{
+		Version:    exporter.DocumentVersion,
+		ID:         conv.ID,
+		Title:      conv.Title,
+		CreatedAt:  conv.CreatedAt,
+		UpdatedAt:  conv.UpdatedAt,
+		ExportedAt: time.Now(),
+		Messages:   make([]exporter.Message, 0, len(conv.Messages)),
+	}
+
+	if conv.CategoryID != nil {
+		path, err := s.categoryRepo.GetPath(*conv.CategoryID, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range path {
+			doc.CategoryPath = append(doc.CategoryPath, category.Name)
+		}
+	}
+
+	seenModels := make(map[string]bool)
+	for _, msg := range conv.Messages {
+		exported := exporter.Message{
+			Role:      msg.Role,
+			Content:   msg.Content,
+			ModelID:   msg.ModelID,
+			CreatedAt: msg.CreatedAt,
+		}
+		if includeReasoning {
+			exported.Reasoning = msg.ReasoningContent
+		}
+		doc.Messages = append(doc.Messages, exported)
+
+		if msg.ModelID != "" && !seenModels[msg.ModelID] {
+			seenModels[msg.ModelID] = true
+			doc.Models = append(doc.Models, msg.ModelID)
+		}
+	}
+
+	return doc, nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/importer"
+	"ai-qa-backend/internal/repository"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+type ImportService interface {
+	StartImport(userID uint, data []byte, format string, categoryID *uint) (*model.Job, error)
+}
+
+type importService struct {
+	convRepo     repository.ConversationRepository
+	categoryRepo repository.CategoryRepository
+	jobRepo      repository.JobRepository
+}
+
+func NewImportService(
+	convRepo repository.ConversationRepository,
+	categoryRepo repository.CategoryRepository,
+	jobRepo repository.JobRepository,
+) ImportService {
+	return &importService{
+		convRepo:     convRepo,
+		categoryRepo: categoryRepo,
+		jobRepo:      jobRepo,
+	}
+}
+
+func (s *importService) StartImport(userID uint, data []byte, format string, categoryID *uint) (*model.Job, error) {
+	if categoryID != nil {
+		if _, err := s.categoryRepo.GetByID(*categoryID, userID); err != nil {
+			return nil, errors.New("target category not found or permission denied")
+		}
+	}
+
+	convs, err := importer.Parse(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := startJobProgress(s.jobRepo, userID, JobTypeImport, len(convs))
+	if err != nil {
+		return nil, err
+	}
+
+	job := *progress.job
+	go s.runImport(progress, userID, convs, categoryID)
+
+	return &job, nil
+}
+
+func (s *importService) runImport(progress *jobProgress, userID uint, convs []importer.Conversation, categoryID *uint) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("ERROR: Import job %d panicked: %v", progress.job.ID, r)
+			progress.finish(errors.New("import aborted unexpectedly"))
+		}
+	}()
+
+	progress.running()
+	for i, imported := range convs {
+		result := JobItemResult{Index: i, Title: imported.Title}
+		conv, err := s.importConversation(userID, imported, categoryID)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.ConversationID = conv.ID
+		}
+		progress.record(result)
+	}
+	progress.finish(nil)
+	log.Printf("INFO: Import job %d finished: %d imported, %d failed", progress.job.ID, progress.job.Processed-progress.job.Failed, progress.job.Failed)
+}
+
+func (s *importService) importConversation(userID uint, imported importer.Conversation, categoryID *uint) (*model.Conversation, error) {
+	if len(imported.Messages) == 0 {
+		return nil, errors.New("conversation has no importable messages")
+	}
+
+	title := strings.TrimSpace(imported.Title)
+	if title == "" {
+		title = "Imported Chat"
+	}
+	if len([]rune(title)) > 255 {
+		title = string([]rune(title)[:255])
+	}
+
+	createdAt := imported.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = imported.Messages[0].CreatedAt
+	}
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	updatedAt := imported.UpdatedAt
+	if updatedAt.IsZero() || updatedAt.Before(createdAt) {
+		updatedAt = createdAt
+	}
+
+	conv := &model.Conversation{
+		BaseModel:           model.BaseModel{CreatedAt: createdAt, UpdatedAt: updatedAt},
+		UserID:              userID,
+		Title:               title,
+		IsTitleUserModified: true,
+		CategoryID:          categoryID,
+	}
+
+	messages := make([]*model.Message, 0, len(imported.Messages))
+	lastCreatedAt := createdAt
+	for _, msg := range imported.Messages {
+		msgCreatedAt := msg.CreatedAt
+		if msgCreatedAt.IsZero() {
+			msgCreatedAt = lastCreatedAt
+		}
+		lastCreatedAt = msgCreatedAt
+		messages = append(messages, &model.Message{
+			BaseModel:        model.BaseModel{CreatedAt: msgCreatedAt, UpdatedAt: msgCreatedAt},
+			Role:             msg.Role,
+			Content:          msg.Content,
+			ReasoningContent: msg.Reasoning,
+			ModelID:          msg.ModelID,
+		})
+	}
+
+	if err := s.convRepo.CreateWithMessages(conv, messages); err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
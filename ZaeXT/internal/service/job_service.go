@@ -0,0 +1,166 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/repository"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	JobTypeImport       = "import"
+	JobTypeAutoClassify = "auto_classify"
+)
+
+type JobItemResult struct {
+	Index            int               `json:"index"`
+	Title            string            `json:"title,omitempty"`
+	ConversationID   uint              `json:"conversation_id,omitempty"`
+	CategoryID       *uint             `json:"category_id,omitempty"`
+	ProposedCategory *CategoryProposal `json:"proposed_category,omitempty"`
+	Confidence       float64           `json:"confidence,omitempty"`
+	SuggestedTagIDs  []uint            `json:"suggested_tag_ids,omitempty"`
+	DryRun           bool              `json:"dry_run,omitempty"`
+	Error            string            `json:"error,omitempty"`
+}
+
+type JobService interface {
+	GetJob(jobID, userID uint) (*model.Job, error)
+	FailOrphanedJobs() error
+	FailStaleJobs() (int64, error)
+}
+
+type jobService struct {
+	jobRepo repository.JobRepository
+}
+
+func NewJobService(jobRepo repository.JobRepository) JobService {
+	return &jobService{jobRepo: jobRepo}
+}
+
+func (s *jobService) GetJob(jobID, userID uint) (*model.Job, error) {
+	job, err := s.jobRepo.GetByID(jobID, userID)
+	if err != nil {
+		return nil, errors.New("job not found or permission denied")
+	}
+	return job, nil
+}
+
+func (s *jobService) FailOrphanedJobs() error {
+	failed, err := s.jobRepo.FailUnfinished(instanceID(), time.Now().Add(-configs.Conf.Jobs.HeartbeatTimeout), "job interrupted by server restart")
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		log.Printf("INFO: Marked %d orphaned jobs as failed", failed)
+	}
+	return nil
+}
+
+func (s *jobService) FailStaleJobs() (int64, error) {
+	return s.jobRepo.FailUnfinished("", time.Now().Add(-configs.Conf.Jobs.HeartbeatTimeout), "job lost its heartbeat")
+}
+
+func instanceID() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+const (
+	jobProgressSaveEvery    = 50
+	jobProgressSaveInterval = 2 * time.Second
+)
+
+type jobProgress struct {
+	jobRepo repository.JobRepository
+	job     *model.Job
+	results []JobItemResult
+	savedAt time.Time
+	stop    chan struct{}
+}
+
+func startJobProgress(jobRepo repository.JobRepository, userID uint, jobType string, total int) (*jobProgress, error) {
+	now := time.Now()
+	job := &model.Job{
+		UserID:      userID,
+		Type:        jobType,
+		Status:      model.JobStatusPending,
+		Total:       total,
+		Owner:       instanceID(),
+		HeartbeatAt: &now,
+	}
+	if err := jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+	return &jobProgress{jobRepo: jobRepo, job: job}, nil
+}
+
+func (p *jobProgress) running() {
+	p.job.Status = model.JobStatusRunning
+	p.save()
+
+	p.stop = make(chan struct{})
+	go p.heartbeat(p.job.ID, configs.Conf.Jobs.HeartbeatTimeout/4)
+}
+
+func (p *jobProgress) heartbeat(jobID uint, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			if err := p.jobRepo.Heartbeat(jobID, now); err != nil {
+				log.Printf("ERROR: Failed to record heartbeat of job %d: %v", jobID, err)
+			}
+		}
+	}
+}
+
+func (p *jobProgress) record(result JobItemResult) {
+	p.results = append(p.results, result)
+	p.job.Processed++
+	if result.Error != "" {
+		p.job.Failed++
+	}
+	if p.job.Processed%jobProgressSaveEvery == 0 || time.Since(p.savedAt) >= jobProgressSaveInterval {
+		p.save()
+	}
+}
+
+func (p *jobProgress) finish(err error) {
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+	now := time.Now()
+	p.job.FinishedAt = &now
+	p.job.Status = model.JobStatusSucceeded
+	if err != nil {
+		p.job.Status = model.JobStatusFailed
+		p.job.Error = err.Error()
+	}
+	p.save()
+}
+
+func (p *jobProgress) save() {
+	if p.results != nil {
+		resultsJSON, _ := json.Marshal(p.results)
+		p.job.Results = string(resultsJSON)
+	}
+	now := time.Now()
+	p.job.HeartbeatAt = &now
+	if err := p.jobRepo.Update(p.job); err != nil {
+		log.Printf("ERROR: Failed to update progress of job %d: %v", p.job.ID, err)
+	}
+	p.savedAt = now
+}
@@ -4,27 +4,72 @@ import (
 	"ai-qa-backend/internal/configs"
 	"ai-qa-backend/internal/model"
 	"ai-qa-backend/internal/repository"
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+type TrashedConversation struct {
+	*model.Conversation
+	PurgeAt *time.Time
+}
+
+type TrashedCategory struct {
+	*model.Category
+	PurgeAt *time.Time
+}
+
 type RecycleBinService interface {
-	List(userID uint) ([]*model.Conversation, error)
+	List(userID uint) ([]*TrashedConversation, error)
 	Restore(convID, userID uint) error
 	PermanentDelete(convID, userID uint) error
-	CleanupExpired() (int64, error)
+	BatchRestore(convIDs []uint, userID uint) ([]BatchItemResult, error)
+	BatchPermanentDelete(convIDs []uint, userID uint) ([]BatchItemResult, error)
+	PurgeExpired() (*model.PurgeRun, error)
+	Empty(userID uint) (*repository.RecycleBinEmptyResult, error)
+	ListCategories(userID uint) ([]*TrashedCategory, error)
+	RestoreCategory(id, userID uint) (*repository.CategoryRestoreResult, error)
+	PermanentDeleteCategory(id, userID uint) error
 }
 
+const recycleBinPurgeLock = "ai_qa_recycle_bin_purge"
+
+var ErrPurgeInProgress = errors.New("recycle bin purge is already running on another instance")
+
 type recycleBinService struct {
-	convRepo repository.ConversationRepository
+	convRepo     repository.ConversationRepository
+	categoryRepo repository.CategoryRepository
+	userRepo     repository.UserRepository
+	purgeRepo    repository.PurgeRepository
 }
 
-func NewRecycleBinService(convRepo repository.ConversationRepository) RecycleBinService {
-	return &recycleBinService{convRepo: convRepo}
+func NewRecycleBinService(convRepo repository.ConversationRepository, categoryRepo repository.CategoryRepository, userRepo repository.UserRepository, purgeRepo repository.PurgeRepository) RecycleBinService {
+	return &recycleBinService{convRepo: convRepo, categoryRepo: categoryRepo, userRepo: userRepo, purgeRepo: purgeRepo}
 }
 
-func (s *recycleBinService) List(userID uint) ([]*model.Conversation, error) {
-	return s.convRepo.ListDeletedByUserID(userID)
+func (s *recycleBinService) List(userID uint) ([]*TrashedConversation, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	convs, err := s.convRepo.ListDeletedByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	retentionDays := EffectiveRetentionDays(user)
+	trashed := make([]*TrashedConversation, len(convs))
+	for i, conv := range convs {
+		trashed[i] = &TrashedConversation{Conversation: conv}
+		if conv.ArchivedAt == nil {
+			trashed[i].PurgeAt = purgeAt(conv.DeletedAt.Time, retentionDays)
+		}
+	}
+	return trashed, nil
 }
 
 func (s *recycleBinService) Restore(convID, userID uint) error {
@@ -32,20 +77,144 @@ func (s *recycleBinService) Restore(convID, userID uint) error {
 }
 
 func (s *recycleBinService) PermanentDelete(convID, userID uint) error {
-	return s.convRepo.PermanentDeleteByID(convID, userID)
+	err := s.convRepo.PermanentDeleteByID(convID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("conversation not found in recycle bin")
+	}
+	return err
 }
 
-func (s *recycleBinService) CleanupExpired() (int64, error) {
-	retentionDays := configs.Conf.RecycleBin.RetentionDays
-	if retentionDays <= 0 {
-		return 0, nil
+func (s *recycleBinService) BatchRestore(convIDs []uint, userID uint) ([]BatchItemResult, error) {
+	affected, err := s.convRepo.RestoreByIDs(convIDs, userID)
+	if err != nil {
+		return nil, err
 	}
+	return buildBatchResults(convIDs, affected, "conversation not found in recycle bin"), nil
+}
 
-	cutoffTime := time.Now().AddDate(0, 0, -retentionDays)
-	deletedCount, err := s.convRepo.PermanentDeleteBefore(cutoffTime)
+func (s *recycleBinService) BatchPermanentDelete(convIDs []uint, userID uint) ([]BatchItemResult, error) {
+	affected, err := s.convRepo.PermanentDeleteByIDs(convIDs, userID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to cleanup expired conversations: %w", err)
+		return nil, err
 	}
+	return buildBatchResults(convIDs, affected, "conversation not found in recycle bin"), nil
+}
 
-	return deletedCount, nil
+func (s *recycleBinService) Empty(userID uint) (*repository.RecycleBinEmptyResult, error) {
+	return s.convRepo.EmptyRecycleBin(userID)
+}
+
+func (s *recycleBinService) PurgeExpired() (*model.PurgeRun, error) {
+	release, acquired, err := s.purgeRepo.TryLock(context.Background(), recycleBinPurgeLock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire purge lock: %w", err)
+	}
+	if !acquired {
+		return nil, ErrPurgeInProgress
+	}
+	defer func() {
+		if err := release(); err != nil {
+			log.Printf("Failed to release recycle bin purge lock: %v", err)
+		}
+	}()
+
+	run := &model.PurgeRun{
+		Instance:  instanceID(),
+		Status:    model.PurgeRunStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.purgeRepo.CreateRun(run); err != nil {
+		return nil, err
+	}
+
+	err = forEachRetentionGroup(s.userRepo, func(cutoff time.Time, scope repository.UserScope) error {
+		return s.purgeInBatches(run, func(limit int) (*repository.PurgeBatchResult, error) {
+			return s.convRepo.PermanentDeleteBefore(cutoff, scope, limit)
+		})
+	})
+	if err == nil {
+		err = forEachRetentionGroup(s.userRepo, func(cutoff time.Time, scope repository.UserScope) error {
+			return s.purgeInBatches(run, func(limit int) (*repository.PurgeBatchResult, error) {
+				return s.categoryRepo.PurgeDeletedBefore(cutoff, scope, limit)
+			})
+		})
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.Status = model.PurgeRunStatusSucceeded
+	if err != nil {
+		run.Status = model.PurgeRunStatusFailed
+		run.LastError = err.Error()
+	}
+	if updateErr := s.purgeRepo.UpdateRun(run); updateErr != nil {
+		log.Printf("Failed to record recycle bin purge run %d: %v", run.ID, updateErr)
+	}
+
+	if err != nil {
+		return run, fmt.Errorf("failed to purge expired recycle bin items: %w", err)
+	}
+	return run, nil
+}
+
+func (s *recycleBinService) purgeInBatches(run *model.PurgeRun, purge func(limit int) (*repository.PurgeBatchResult, error)) error {
+	batchSize := max(configs.Conf.RecycleBin.PurgeBatchSize, 1)
+	for {
+		batch, err := purge(batchSize)
+		if err != nil {
+			return err
+		}
+
+		purged := batch.Conversations + batch.Categories
+		if purged == 0 {
+			return nil
+		}
+		run.Batches++
+		run.Conversations += batch.Conversations
+		run.Messages += batch.Messages
+		run.Categories += batch.Categories
+		if err := s.purgeRepo.UpdateRun(run); err != nil {
+			log.Printf("Failed to record recycle bin purge progress for run %d: %v", run.ID, err)
+		}
+
+		if purged < int64(batchSize) {
+			return nil
+		}
+		time.Sleep(configs.Conf.RecycleBin.PurgeBatchPause)
+	}
+}
+
+func (s *recycleBinService) ListCategories(userID uint) ([]*TrashedCategory, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.ListDeletedByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	retentionDays := EffectiveRetentionDays(user)
+	trashed := make([]*TrashedCategory, len(categories))
+	for i, category := range categories {
+		trashed[i] = &TrashedCategory{Category: category, PurgeAt: purgeAt(category.DeletedAt.Time, retentionDays)}
+	}
+	return trashed, nil
+}
+
+func (s *recycleBinService) RestoreCategory(id, userID uint) (*repository.CategoryRestoreResult, error) {
+	result, err := s.categoryRepo.RestoreByID(id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("category not found in recycle bin")
+	}
+	return result, err
+}
+
+func (s *recycleBinService) PermanentDeleteCategory(id, userID uint) error {
+	err := s.categoryRepo.PermanentDeleteByID(id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("category not found in recycle bin")
+	}
+	return err
 }
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidRetention = errors.New("invalid retention days")
+
+func RetentionBounds(tier string) (int, int) {
+	cfg := configs.Conf.RecycleBin
+	maxDays := cfg.MaxRetentionDays
+	if tierMax := cfg.TierMaxRetentionDays[tier]; tierMax > maxDays {
+		maxDays = tierMax
+	}
+	return cfg.MinRetentionDays, maxDays
+}
+
+func EffectiveRetentionDays(user *model.User) int {
+	if user.RetentionDays == nil {
+		return configs.Conf.RecycleBin.RetentionDays
+	}
+	minDays, maxDays := RetentionBounds(user.Tier)
+	days := max(*user.RetentionDays, minDays)
+	if maxDays > 0 {
+		days = min(days, maxDays)
+	}
+	return days
+}
+
+func validateRetentionDays(tier string, days int) error {
+	minDays, maxDays := RetentionBounds(tier)
+	minDays = max(minDays, 1)
+	if days < minDays || (maxDays > 0 && days > maxDays) {
+		return fmt.Errorf("%w: must be between %d and %d for tier %s", ErrInvalidRetention, minDays, maxDays, tier)
+	}
+	return nil
+}
+
+func purgeAt(deletedAt time.Time, retentionDays int) *time.Time {
+	if retentionDays <= 0 {
+		return nil
+	}
+	at := deletedAt.AddDate(0, 0, retentionDays)
+	return &at
+}
+
+func forEachRetentionGroup(userRepo repository.UserRepository, fn func(cutoff time.Time, scope repository.UserScope) error) error {
+	overrides, err := userRepo.ListWithRetentionOverride()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	groups := make(map[int][]uint)
+	overrideIDs := make([]uint, 0, len(overrides))
+	for _, user := range overrides {
+		days := EffectiveRetentionDays(user)
+		groups[days] = append(groups[days], user.ID)
+		overrideIDs = append(overrideIDs, user.ID)
+	}
+
+	if days := configs.Conf.RecycleBin.RetentionDays; days > 0 {
+		if err := fn(now.AddDate(0, 0, -days), repository.UserScope{Exclude: overrideIDs}); err != nil {
+			return err
+		}
+	}
+	for days, userIDs := range groups {
+		if days <= 0 {
+			continue
+		}
+		if err := fn(now.AddDate(0, 0, -days), repository.UserScope{Include: userIDs}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
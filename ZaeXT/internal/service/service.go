@@ -1,22 +1,40 @@
 package service
 
 import (
+	"ai-qa-backend/internal/pkg/notifier"
 	"ai-qa-backend/internal/repository"
 )
 
 type Service struct {
+	Admin      AdminService
+	Auth       AuthService
 	User       UserService
 	Category   CategoryService
 	Chat       ChatService
 	RecycleBin RecycleBinService
+	Export     ExportService
+	Import     ImportService
+	Job        JobService
+	Share      ShareService
+	Tag        TagService
+	Task       TaskService
 }
 
-func NewService(repo *repository.Repository, aiAdapter AIAdapter) *Service {
-	userService := NewUserService(repo.User, repo.Category)
+func NewService(repo *repository.Repository, aiAdapter AIAdapter, notificationSender notifier.Notifier) *Service {
+	authService := NewAuthService(repo.User, repo.Session)
+	userService := NewUserService(repo.User, repo.Category, repo.PasswordReset, authService, notificationSender)
 	return &Service{
+		Admin:      NewAdminService(repo.Admin, repo.User, userService, authService, aiAdapter),
+		Auth:       authService,
 		User:       userService,
 		Category:   NewCategoryService(repo.Category),
-		Chat:       NewChatService(repo.Conversation, repo.Message, repo.User, repo.Category, aiAdapter),
-		RecycleBin: NewRecycleBinService(repo.Conversation),
+		Chat:       NewChatService(repo.Conversation, repo.Message, repo.User, repo.Category, repo.Tag, repo.Job, repo.Task, aiAdapter),
+		RecycleBin: NewRecycleBinService(repo.Conversation, repo.Category, repo.User, repo.Purge),
+		Export:     NewExportService(repo.Conversation, repo.Category),
+		Import:     NewImportService(repo.Conversation, repo.Category, repo.Job),
+		Job:        NewJobService(repo.Job),
+		Share:      NewShareService(repo.Share, repo.Conversation, repo.Message),
+		Tag:        NewTagService(repo.Tag, repo.Conversation),
+		Task:       NewTaskService(repo.Task, repo.Conversation),
 	}
 }
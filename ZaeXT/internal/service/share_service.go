@@ -0,0 +1,212 @@
+package service
+
+import (
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/pkg/token"
+	"ai-qa-backend/internal/repository"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+type SharedMessage struct {
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SharedConversation struct {
+	Title     string          `json:"title"`
+	CreatedAt time.Time       `json:"created_at"`
+	SharedAt  time.Time       `json:"shared_at"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	ViewCount int64           `json:"view_count"`
+	Messages  []SharedMessage `json:"messages"`
+}
+
+type ShareService interface {
+	CreateShare(convID, userID uint, expiresIn time.Duration) (*model.ConversationShare, error)
+	ListShares(convID, userID uint) ([]*model.ConversationShare, error)
+	UpdateShare(shareID, userID uint, expiresIn *time.Duration, refreshSnapshot bool) (*model.ConversationShare, error)
+	RevokeShare(shareID, userID uint) error
+	GetSharedConversation(shareToken string) (*SharedConversation, error)
+}
+
+type shareService struct {
+	shareRepo repository.ShareRepository
+	convRepo  repository.ConversationRepository
+	msgRepo   repository.MessageRepository
+}
+
+func NewShareService(
+	shareRepo repository.ShareRepository,
+	convRepo repository.ConversationRepository,
+	msgRepo repository.MessageRepository,
+) ShareService {
+	return &shareService{
+		shareRepo: shareRepo,
+		convRepo:  convRepo,
+		msgRepo:   msgRepo,
+	}
+}
+
+func (s *shareService) CreateShare(convID, userID uint, expiresIn time.Duration) (*model.ConversationShare, error) {
+	conv, err := s.convRepo.GetByID(convID, userID)
+	if err != nil {
+		return nil, errors.New("conversation not found or permission denied")
+	}
+	if conv.IsTemporary {
+		return nil, errors.New("temporary conversations cannot be shared")
+	}
+
+	snapshot, err := s.buildSnapshot(conv)
+	if err != nil {
+		return nil, err
+	}
+	shareToken, err := token.Generate(24)
+	if err != nil {
+		return nil, err
+	}
+
+	share := &model.ConversationShare{
+		UserID:         userID,
+		ConversationID: convID,
+		Token:          shareToken,
+		Snapshot:       snapshot,
+		SnapshotAt:     time.Now(),
+	}
+	if expiresIn > 0 {
+		expiresAt := time.Now().Add(expiresIn)
+		share.ExpiresAt = &expiresAt
+	}
+
+	if err := s.shareRepo.Create(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+func (s *shareService) ListShares(convID, userID uint) ([]*model.ConversationShare, error) {
+	if _, err := s.convRepo.GetByID(convID, userID); err != nil {
+		return nil, errors.New("conversation not found or permission denied")
+	}
+	return s.shareRepo.ListByConversationID(convID, userID)
+}
+
+func (s *shareService) UpdateShare(shareID, userID uint, expiresIn *time.Duration, refreshSnapshot bool) (*model.ConversationShare, error) {
+	share, err := s.shareRepo.GetByID(shareID, userID)
+	if err != nil {
+		return nil, errors.New("share not found or permission denied")
+	}
+	if share.RevokedAt != nil {
+		return nil, errors.New("share has been revoked")
+	}
+
+	fields := make(map[string]interface{})
+	if expiresIn != nil {
+		share.ExpiresAt = nil
+		if *expiresIn > 0 {
+			expiresAt := time.Now().Add(*expiresIn)
+			share.ExpiresAt = &expiresAt
+		}
+		fields["expires_at"] = share.ExpiresAt
+	}
+
+	if refreshSnapshot {
+		conv, err := s.convRepo.GetByID(share.ConversationID, userID)
+		if err != nil {
+			return nil, errors.New("conversation not found or permission denied")
+		}
+		snapshot, err := s.buildSnapshot(conv)
+		if err != nil {
+			return nil, err
+		}
+		share.Snapshot = snapshot
+		share.SnapshotAt = time.Now()
+		fields["snapshot"] = share.Snapshot
+		fields["snapshot_at"] = share.SnapshotAt
+	}
+
+	if len(fields) == 0 {
+		return share, nil
+	}
+	if err := s.shareRepo.UpdateFields(share.ID, fields); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+func (s *shareService) RevokeShare(shareID, userID uint) error {
+	share, err := s.shareRepo.GetByID(shareID, userID)
+	if err != nil {
+		return errors.New("share not found or permission denied")
+	}
+	if share.RevokedAt != nil {
+		return nil
+	}
+
+	return s.shareRepo.UpdateFields(share.ID, map[string]interface{}{"revoked_at": time.Now()})
+}
+
+func (s *shareService) GetSharedConversation(shareToken string) (*SharedConversation, error) {
+	share, err := s.shareRepo.GetByToken(shareToken)
+	if err != nil {
+		return nil, errors.New("share not found")
+	}
+	if share.RevokedAt != nil {
+		return nil, errors.New("share not found")
+	}
+	if share.ExpiresAt != nil && time.Now().After(*share.ExpiresAt) {
+		return nil, errors.New("share not found")
+	}
+	convs, err := s.convRepo.ListByIDs([]uint{share.ConversationID}, share.UserID)
+	if err != nil || len(convs) == 0 {
+		return nil, errors.New("share not found")
+	}
+
+	var shared SharedConversation
+	if err := json.Unmarshal([]byte(share.Snapshot), &shared); err != nil {
+		return nil, err
+	}
+
+	if err := s.shareRepo.IncrementViewCount(share.ID); err != nil {
+		log.Printf("ERROR: Failed to increment view count for share %d: %v", share.ID, err)
+	}
+	shared.SharedAt = share.SnapshotAt
+	shared.ExpiresAt = share.ExpiresAt
+	shared.ViewCount = share.ViewCount + 1
+	return &shared, nil
+}
+
+func (s *shareService) buildSnapshot(conv *model.Conversation) (string, error) {
+	messages, err := s.msgRepo.GetByConversationID(conv.ID)
+	if err != nil {
+		return "", err
+	}
+	if len(messages) == 0 {
+		return "", errors.New("cannot share an empty conversation")
+	}
+
+	shared := SharedConversation{
+		Title:     conv.Title,
+		CreatedAt: conv.CreatedAt,
+		Messages:  make([]SharedMessage, 0, len(messages)),
+	}
+	for _, msg := range messages {
+		if msg.Role != "user" && msg.Role != "assistant" {
+			continue
+		}
+		shared.Messages = append(shared.Messages, SharedMessage{
+			Role:      msg.Role,
+			Content:   msg.Content,
+			CreatedAt: msg.CreatedAt,
+		})
+	}
+
+	snapshotJSON, err := json.Marshal(shared)
+	if err != nil {
+		return "", err
+	}
+	return string(snapshotJSON), nil
+}
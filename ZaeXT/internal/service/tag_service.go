@@ -0,0 +1,125 @@
+package service
+
+import (
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/repository"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const defaultTagColor = "#6B7280"
+
+var ErrEmptyTagName = errors.New("tag name must not be blank")
+
+type TagService interface {
+	Create(userID uint, name, color string) (*model.Tag, error)
+	List(userID uint) ([]*model.Tag, error)
+	Update(id, userID uint, name, color string) error
+	Delete(id, userID uint) error
+	TagConversation(convID, userID uint, tagIDs []uint) error
+	UntagConversation(convID, userID, tagID uint) error
+}
+
+type tagService struct {
+	tagRepo  repository.TagRepository
+	convRepo repository.ConversationRepository
+}
+
+func NewTagService(tagRepo repository.TagRepository, convRepo repository.ConversationRepository) TagService {
+	return &tagService{tagRepo: tagRepo, convRepo: convRepo}
+}
+
+func (s *tagService) Create(userID uint, name, color string) (*model.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyTagName
+	}
+	if err := s.ensureNameAvailable(userID, name, 0); err != nil {
+		return nil, err
+	}
+	if color == "" {
+		color = defaultTagColor
+	}
+
+	tag := &model.Tag{
+		UserID: userID,
+		Name:   name,
+		Color:  strings.ToUpper(color),
+	}
+	err := s.tagRepo.Create(tag)
+	return tag, err
+}
+
+func (s *tagService) List(userID uint) ([]*model.Tag, error) {
+	return s.tagRepo.ListByUserID(userID)
+}
+
+func (s *tagService) Update(id, userID uint, name, color string) error {
+	tag, err := s.tagRepo.GetByID(id, userID)
+	if err != nil {
+		return errors.New("tag not found or permission denied")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyTagName
+	}
+	if err := s.ensureNameAvailable(userID, name, id); err != nil {
+		return err
+	}
+	tag.Name = name
+	if color != "" {
+		tag.Color = strings.ToUpper(color)
+	}
+	return s.tagRepo.Update(tag)
+}
+
+func (s *tagService) Delete(id, userID uint) error {
+	if err := s.tagRepo.DeleteByID(id, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("tag not found or permission denied")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *tagService) TagConversation(convID, userID uint, tagIDs []uint) error {
+	if _, err := s.convRepo.GetByID(convID, userID); err != nil {
+		return errors.New("conversation not found or permission denied")
+	}
+
+	tags, err := s.tagRepo.ListByIDs(tagIDs, userID)
+	if err != nil {
+		return err
+	}
+	if len(tags) != len(uniqueIDs(tagIDs)) {
+		return errors.New("tag not found or permission denied")
+	}
+	return s.tagRepo.AddToConversation(convID, tags)
+}
+
+func (s *tagService) UntagConversation(convID, userID, tagID uint) error {
+	if _, err := s.convRepo.GetByID(convID, userID); err != nil {
+		return errors.New("conversation not found or permission denied")
+	}
+
+	tag, err := s.tagRepo.GetByID(tagID, userID)
+	if err != nil {
+		return errors.New("tag not found or permission denied")
+	}
+	return s.tagRepo.RemoveFromConversation(convID, tag)
+}
+
+func (s *tagService) ensureNameAvailable(userID uint, name string, selfID uint) error {
+	existing, err := s.tagRepo.GetByName(userID, name)
+	if err == nil && existing.ID != selfID {
+		return errors.New("tag name already exists")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
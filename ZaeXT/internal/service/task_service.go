@@ -0,0 +1,145 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	TaskTypeGenerateTitle = "generate_title"
+	TaskTypeAutoClassify  = "auto_classify"
+	TaskTypeSummarize     = "summarize"
+	TaskTypeExtractMemory = "extract_memory"
+)
+
+const maxTaskRetryBackoff = time.Hour
+
+type TaskService interface {
+	ListConversationTasks(convID, userID uint) ([]*model.Task, error)
+	ClaimNext(workerID string) (*model.Task, error)
+	Complete(task *model.Task, runErr error)
+	RequeueStale() (int64, error)
+	CleanupFinished() (int64, error)
+}
+
+type taskService struct {
+	taskRepo repository.TaskRepository
+	convRepo repository.ConversationRepository
+}
+
+func NewTaskService(taskRepo repository.TaskRepository, convRepo repository.ConversationRepository) TaskService {
+	return &taskService{taskRepo: taskRepo, convRepo: convRepo}
+}
+
+func (s *taskService) ListConversationTasks(convID, userID uint) ([]*model.Task, error) {
+	if _, err := s.convRepo.GetByID(convID, userID); err != nil {
+		return nil, errors.New("conversation not found or permission denied")
+	}
+	return s.taskRepo.ListByConversationID(convID, userID)
+}
+
+func (s *taskService) ClaimNext(workerID string) (*model.Task, error) {
+	return s.taskRepo.ClaimNext(workerID, time.Now())
+}
+
+func (s *taskService) Complete(task *model.Task, runErr error) {
+	lockedBy := task.LockedBy
+	applyTaskOutcome(task, runErr)
+	released, err := s.taskRepo.Release(task, lockedBy)
+	if err != nil {
+		log.Printf("ERROR: Failed to update task %d: %v", task.ID, err)
+		return
+	}
+	if !released {
+		log.Printf("WARN: Worker %s no longer owns task %d, discarding its outcome", lockedBy, task.ID)
+	}
+}
+
+func (s *taskService) RequeueStale() (int64, error) {
+	stale, err := s.taskRepo.ListStale(time.Now().Add(-configs.Conf.Tasks.LockTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	var released int64
+	for _, task := range stale {
+		lockedBy := task.LockedBy
+		applyTaskOutcome(task, fmt.Errorf("worker %s did not finish the task within %s", lockedBy, configs.Conf.Tasks.LockTimeout))
+		ok, err := s.taskRepo.Release(task, lockedBy)
+		if err != nil {
+			return released, err
+		}
+		if ok {
+			released++
+		}
+	}
+	return released, nil
+}
+
+func (s *taskService) CleanupFinished() (int64, error) {
+	retention := configs.Conf.Tasks.Retention
+	if retention <= 0 {
+		return 0, nil
+	}
+	return s.taskRepo.DeleteFinishedBefore(time.Now().Add(-retention))
+}
+
+func applyTaskOutcome(task *model.Task, runErr error) {
+	now := time.Now()
+	task.LockedAt = nil
+	task.LockedBy = ""
+
+	switch {
+	case runErr == nil:
+		task.Status = model.TaskStatusSucceeded
+		task.LastError = ""
+		task.FinishedAt = &now
+	case task.Attempts >= task.MaxAttempts:
+		task.Status = model.TaskStatusDead
+		task.LastError = runErr.Error()
+		task.FinishedAt = &now
+		log.Printf("ERROR: Task %d (%s) is dead after %d attempts: %v", task.ID, task.Type, task.Attempts, runErr)
+	default:
+		task.Status = model.TaskStatusPending
+		task.LastError = runErr.Error()
+		task.RunAt = now.Add(retryBackoff(task.Attempts))
+		log.Printf("WARN: Task %d (%s) failed on attempt %d, retrying at %s: %v", task.ID, task.Type, task.Attempts, task.RunAt.Format(time.RFC3339), runErr)
+	}
+}
+
+func retryBackoff(attempts int) time.Duration {
+	backoff := configs.Conf.Tasks.RetryBackoff
+	for i := 1; i < attempts && backoff < maxTaskRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxTaskRetryBackoff)
+}
+
+func enqueueConversationTask(taskRepo repository.TaskRepository, conv *model.Conversation, taskType string) {
+	exists, err := taskRepo.ExistsPending(conv.ID, taskType)
+	if err != nil {
+		log.Printf("ERROR: Failed to check pending %s task for conv %d: %v", taskType, conv.ID, err)
+		return
+	}
+	if exists {
+		return
+	}
+
+	convID := conv.ID
+	task := &model.Task{
+		UserID:         conv.UserID,
+		ConversationID: &convID,
+		Type:           taskType,
+		Status:         model.TaskStatusPending,
+		RunAt:          time.Now(),
+		MaxAttempts:    configs.Conf.Tasks.MaxAttempts,
+	}
+	if err := taskRepo.Create(task); err != nil {
+		log.Printf("ERROR: Failed to enqueue %s task for conv %d: %v", taskType, conv.ID, err)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	previous := configs.Conf
+	t.Cleanup(func() { configs.Conf = previous })
+	configs.Conf = &configs.Config{Tasks: configs.TaskConfig{RetryBackoff: 30 * time.Second}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 30 * time.Second},
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: maxTaskRetryBackoff},
+		{attempts: 50, want: maxTaskRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := retryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTaskOutcome(t *testing.T) {
+	previous := configs.Conf
+	t.Cleanup(func() { configs.Conf = previous })
+	configs.Conf = &configs.Config{Tasks: configs.TaskConfig{RetryBackoff: time.Minute}}
+
+	tests := []struct {
+		name       string
+		attempts   int
+		runErr     error
+		wantStatus string
+		wantError  string
+		wantRetry  bool
+	}{
+		{name: "success", attempts: 1, wantStatus: model.TaskStatusSucceeded},
+		{name: "failure with attempts left", attempts: 2, runErr: errors.New("boom"), wantStatus: model.TaskStatusPending, wantError: "boom", wantRetry: true},
+		{name: "failure on the last attempt", attempts: 3, runErr: errors.New("boom"), wantStatus: model.TaskStatusDead, wantError: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockedAt := time.Now()
+			task := &model.Task{
+				Status:      model.TaskStatusRunning,
+				Attempts:    tt.attempts,
+				MaxAttempts: 3,
+				LockedAt:    &lockedAt,
+				LockedBy:    "worker-1",
+				LastError:   "previous",
+			}
+			before := time.Now()
+			applyTaskOutcome(task, tt.runErr)
+
+			if task.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", task.Status, tt.wantStatus)
+			}
+			if task.LastError != tt.wantError {
+				t.Errorf("LastError = %q, want %q", task.LastError, tt.wantError)
+			}
+			if task.LockedAt != nil || task.LockedBy != "" {
+				t.Errorf("lease not cleared: LockedAt = %v, LockedBy = %q", task.LockedAt, task.LockedBy)
+			}
+			if tt.wantRetry {
+				if task.FinishedAt != nil {
+					t.Errorf("FinishedAt = %v, want nil for a retried task", task.FinishedAt)
+				}
+				if task.RunAt.Before(before.Add(retryBackoff(tt.attempts))) {
+					t.Errorf("RunAt = %v, want at least %s from now", task.RunAt, retryBackoff(tt.attempts))
+				}
+			} else if task.FinishedAt == nil {
+				t.Errorf("FinishedAt = nil, want it set")
+			}
+		})
+	}
+}
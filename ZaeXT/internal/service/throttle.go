@@ -0,0 +1,26 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+type intervalThrottle struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+func (t *intervalThrottle) Wait(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(interval)
+	t.mu.Unlock()
+	time.Sleep(time.Until(at))
+}
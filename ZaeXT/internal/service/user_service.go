@@ -4,7 +4,9 @@ import (
 	"ai-qa-backend/internal/configs"
 	"ai-qa-backend/internal/model"
 	"ai-qa-backend/internal/pkg/hash"
-	"ai-qa-backend/internal/pkg/jwt"
+	"ai-qa-backend/internal/pkg/notifier"
+	"ai-qa-backend/internal/pkg/password"
+	"ai-qa-backend/internal/pkg/token"
 	"ai-qa-backend/internal/repository"
 	"errors"
 	"log"
@@ -13,36 +15,67 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	ErrAccountDisabled   = errors.New("account is disabled")
+	ErrIncorrectPassword = errors.New("incorrect password")
+	ErrSamePassword      = errors.New("new password must differ from the current password")
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
 type UserService interface {
 	Register(username, password string) error
-	Login(username, password string) (string, error)
+	Login(username, password string, meta SessionMeta) (*TokenPair, error)
 	GetUserByID(id uint) (*model.User, error)
 	UpdateUserMemory(id uint, memoryInfo string) error
+	UpdateRetention(id uint, retentionDays *int) (*model.User, error)
+	ResolveAccess(id uint) (*UserAccess, error)
+	InvalidateAccess(id uint)
+	ChangePassword(id, sessionID uint, oldPassword, newPassword string) (int64, error)
+	RequestPasswordReset(username, ip string)
+	ResetPassword(resetToken, newPassword string) error
+	CleanupExpiredResetTokens() (int64, error)
 }
 
 type userService struct {
-	userRepo     repository.UserRepository
-	categoryRepo repository.CategoryRepository
-	jwtHelper    *jwt.JWT
-	jwtExpiresAt time.Duration
+	userRepo       repository.UserRepository
+	categoryRepo   repository.CategoryRepository
+	resetRepo      repository.PasswordResetRepository
+	authService    AuthService
+	notifier       notifier.Notifier
+	access         *accessCache
+	passwordPolicy password.Policy
 }
 
-func NewUserService(userRepo repository.UserRepository, categoryRepo repository.CategoryRepository) UserService {
+func NewUserService(userRepo repository.UserRepository, categoryRepo repository.CategoryRepository, resetRepo repository.PasswordResetRepository, authService AuthService, notificationSender notifier.Notifier) UserService {
+	cfg := configs.Conf.Password
 	return &userService{
 		userRepo:     userRepo,
 		categoryRepo: categoryRepo,
-		jwtHelper:    jwt.NewJWT(configs.Conf.JWT.Secret),
-		jwtExpiresAt: configs.Conf.JWT.Expiration,
+		resetRepo:    resetRepo,
+		authService:  authService,
+		notifier:     notificationSender,
+		access:       newAccessCache(configs.Conf.JWT.AccessCacheTTL),
+		passwordPolicy: password.Policy{
+			MinLength:     cfg.MinLength,
+			RequireUpper:  cfg.RequireUpper,
+			RequireLower:  cfg.RequireLower,
+			RequireDigit:  cfg.RequireDigit,
+			RequireSymbol: cfg.RequireSymbol,
+		},
 	}
 }
 
-func (s *userService) Register(username, password string) error {
+func (s *userService) Register(username, plainPassword string) error {
+	if err := s.passwordPolicy.Validate(plainPassword); err != nil {
+		return err
+	}
+
 	_, err := s.userRepo.GetByUsername(username)
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.New("username already exists")
 	}
 
-	hashedPassword, err := hash.HashPassword(password)
+	hashedPassword, err := hash.HashPassword(plainPassword)
 	if err != nil {
 		return err
 	}
@@ -51,6 +84,7 @@ func (s *userService) Register(username, password string) error {
 		Username:     username,
 		PasswordHash: hashedPassword,
 		Tier:         "free",
+		Role:         model.RoleUser,
 	}
 
 	if err := s.userRepo.Create(user); err != nil {
@@ -62,25 +96,23 @@ func (s *userService) Register(username, password string) error {
 	return nil
 }
 
-func (s *userService) Login(username, password string) (string, error) {
+func (s *userService) Login(username, plainPassword string, meta SessionMeta) (*TokenPair, error) {
 	user, err := s.userRepo.GetByUsername(username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", errors.New("invalid username or password")
+			return nil, errors.New("invalid username or password")
 		}
-		return "", err
+		return nil, err
 	}
 
-	if !hash.CheckPasswordHash(password, user.PasswordHash) {
-		return "", errors.New("invalid username or password")
+	if !hash.CheckPasswordHash(plainPassword, user.PasswordHash) {
+		return nil, errors.New("invalid username or password")
 	}
-
-	token, err := s.jwtHelper.GenerateToken(user.ID, user.Username, user.Tier, s.jwtExpiresAt)
-	if err != nil {
-		return "", err
+	if user.DisabledAt != nil {
+		return nil, ErrAccountDisabled
 	}
 
-	return token, nil
+	return s.authService.IssueTokens(user, meta)
 }
 
 func (s *userService) GetUserByID(id uint) (*model.User, error) {
@@ -88,13 +120,160 @@ func (s *userService) GetUserByID(id uint) (*model.User, error) {
 }
 
 func (s *userService) UpdateUserMemory(id uint, memoryInfo string) error {
+	if _, err := s.userRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	return s.userRepo.UpdateMemory(id, memoryInfo)
+}
+
+func (s *userService) UpdateRetention(id uint, retentionDays *int) (*model.User, error) {
 	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if retentionDays != nil {
+		if err := validateRetentionDays(user.Tier, *retentionDays); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.UpdateRetentionDays(id, retentionDays); err != nil {
+		return nil, err
+	}
+	user.RetentionDays = retentionDays
+	return user, nil
+}
+
+func (s *userService) ResolveAccess(id uint) (*UserAccess, error) {
+	if access, ok := s.access.get(id); ok {
+		return &access, nil
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	access := UserAccess{
+		Tier:                  user.Tier,
+		Role:                  user.Role,
+		Disabled:              user.DisabledAt != nil,
+		PasswordResetRequired: user.PasswordResetRequired,
+	}
+	s.access.set(id, access)
+	return &access, nil
+}
+
+func (s *userService) InvalidateAccess(id uint) {
+	s.access.invalidate(id)
+}
+
+func (s *userService) ChangePassword(id, sessionID uint, oldPassword, newPassword string) (int64, error) {
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+	if !hash.CheckPasswordHash(oldPassword, user.PasswordHash) {
+		return 0, ErrIncorrectPassword
+	}
+	if oldPassword == newPassword {
+		return 0, ErrSamePassword
+	}
+	if err := s.passwordPolicy.Validate(newPassword); err != nil {
+		return 0, err
+	}
+
+	hashedPassword, err := hash.HashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.userRepo.UpdatePassword(id, hashedPassword); err != nil {
+		return 0, err
+	}
+	s.access.invalidate(id)
+
+	return s.authService.RevokeOtherSessions(id, sessionID)
+}
+
+func (s *userService) RequestPasswordReset(username, ip string) {
+	if err := s.requestPasswordReset(username, ip); err != nil {
+		log.Printf("ERROR: Failed to process password reset request: %v", err)
+	}
+}
+
+func (s *userService) requestPasswordReset(username, ip string) error {
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.DisabledAt != nil {
+		log.Printf("Ignoring password reset request for disabled user %d", user.ID)
+		return nil
+	}
+
+	if limit := configs.Conf.Password.ResetRequestsPerHour; limit > 0 {
+		recent, err := s.resetRepo.CountRecent(user.ID, time.Now().Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		if recent >= int64(limit) {
+			log.Printf("Ignoring password reset request for user %d: hourly limit of %d reached", user.ID, limit)
+			return nil
+		}
+	}
+
+	plainToken, err := token.Generate(32)
+	if err != nil {
+		return err
+	}
+	resetToken := &model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: token.Hash(plainToken),
+		ExpiresAt: time.Now().Add(configs.Conf.Password.ResetTokenTTL),
+		RequestIP: truncate(ip, 64),
+	}
+	if err := s.resetRepo.Create(resetToken); err != nil {
+		return err
+	}
+
+	return s.notifier.SendPasswordReset(notifier.PasswordReset{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Token:     plainToken,
+		ExpiresAt: resetToken.ExpiresAt,
+	})
+}
+
+func (s *userService) ResetPassword(resetToken, newPassword string) error {
+	if err := s.passwordPolicy.Validate(newPassword); err != nil {
+		return err
+	}
+	hashedPassword, err := hash.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 
-	user.MemoryInfo = memoryInfo
-	return s.userRepo.Update(user)
+	userID, err := s.resetRepo.ResetPassword(token.Hash(resetToken), hashedPassword, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	s.access.invalidate(userID)
+
+	if _, err := s.authService.LogoutAll(userID); err != nil {
+		log.Printf("Failed to revoke sessions for user %d after password reset: %v", userID, err)
+	}
+	return nil
+}
+
+func (s *userService) CleanupExpiredResetTokens() (int64, error) {
+	return s.resetRepo.DeleteExpired(time.Now())
 }
 
 func (s *userService) createDefaultCategoriesForUser(userID uint) {
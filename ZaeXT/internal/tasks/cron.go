@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"ai-qa-backend/internal/service"
+	"errors"
 	"log"
 
 	"github.com/robfig/cron/v3"
@@ -12,17 +13,88 @@ func StartCronJobs(services *service.Service) *cron.Cron {
 
 	_, err := c.AddFunc("0 0 4 * * *", func() {
 		log.Println("Cron Job [CleanupRecycleBin] started...")
-		deletedCount, err := services.RecycleBin.CleanupExpired()
-		if err != nil {
+		run, err := services.RecycleBin.PurgeExpired()
+		switch {
+		case errors.Is(err, service.ErrPurgeInProgress):
+			log.Println("Cron Job [CleanupRecycleBin] skipped, another instance holds the purge lock.")
+		case err != nil:
 			log.Printf("Cron Job [CleanupRecycleBin] ERROR: %v", err)
-		} else {
-			log.Printf("Cron Job [CleanupRecycleBin] finished. Permanently deleted %d conversations.", deletedCount)
+		default:
+			log.Printf("Cron Job [CleanupRecycleBin] finished in %dms (run %d, %d batches). Permanently deleted %d conversations, %d messages and %d categories.",
+				run.DurationMs, run.ID, run.Batches, run.Conversations, run.Messages, run.Categories)
 		}
 	})
 	if err != nil {
 		log.Fatalf("Failed to add cron job [CleanupRecycleBin]: %v", err)
 	}
 
+	_, err = c.AddFunc("0 */10 * * * *", func() {
+		deletedCount, err := services.Chat.CleanupIdleTemporaryConversations()
+		if err != nil {
+			log.Printf("Cron Job [CleanupTemporaryConversations] ERROR: %v", err)
+		} else if deletedCount > 0 {
+			log.Printf("Cron Job [CleanupTemporaryConversations] finished. Purged %d idle temporary conversations.", deletedCount)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add cron job [CleanupTemporaryConversations]: %v", err)
+	}
+
+	_, err = c.AddFunc("30 * * * * *", func() {
+		requeued, err := services.Task.RequeueStale()
+		if err != nil {
+			log.Printf("Cron Job [RequeueStaleTasks] ERROR: %v", err)
+		} else if requeued > 0 {
+			log.Printf("Cron Job [RequeueStaleTasks] finished. Released %d stale background tasks.", requeued)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add cron job [RequeueStaleTasks]: %v", err)
+	}
+
+	_, err = c.AddFunc("45 * * * * *", func() {
+		failed, err := services.Job.FailStaleJobs()
+		if err != nil {
+			log.Printf("Cron Job [FailStaleJobs] ERROR: %v", err)
+		} else if failed > 0 {
+			log.Printf("Cron Job [FailStaleJobs] finished. Marked %d jobs without heartbeat as failed.", failed)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add cron job [FailStaleJobs]: %v", err)
+	}
+
+	_, err = c.AddFunc("0 30 4 * * *", func() {
+		deletedCount, err := services.Auth.CleanupExpired()
+		if err != nil {
+			log.Printf("Cron Job [CleanupExpiredSessions] ERROR: %v", err)
+		} else {
+			log.Printf("Cron Job [CleanupExpiredSessions] finished. Deleted %d expired sessions and revoked tokens.", deletedCount)
+		}
+
+		purgedCount, err := services.User.CleanupExpiredResetTokens()
+		if err != nil {
+			log.Printf("Cron Job [CleanupExpiredSessions] ERROR: %v", err)
+		} else {
+			log.Printf("Cron Job [CleanupExpiredSessions] finished. Deleted %d expired password reset tokens.", purgedCount)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add cron job [CleanupExpiredSessions]: %v", err)
+	}
+
+	_, err = c.AddFunc("0 15 4 * * *", func() {
+		deletedCount, err := services.Task.CleanupFinished()
+		if err != nil {
+			log.Printf("Cron Job [CleanupFinishedTasks] ERROR: %v", err)
+		} else {
+			log.Printf("Cron Job [CleanupFinishedTasks] finished. Deleted %d finished background tasks.", deletedCount)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add cron job [CleanupFinishedTasks]: %v", err)
+	}
+
 	go c.Start()
 	log.Println("Cron job scheduler started.")
 	return c
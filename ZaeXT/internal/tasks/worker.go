@@ -0,0 +1,86 @@
+package tasks
+
+import (
+	"ai-qa-backend/internal/configs"
+	"ai-qa-backend/internal/model"
+	"ai-qa-backend/internal/service"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type WorkerPool struct {
+	services *service.Service
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func StartWorkers(services *service.Service) *WorkerPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &WorkerPool{services: services, cancel: cancel}
+
+	hostname, _ := os.Hostname()
+	workers := max(configs.Conf.Tasks.Workers, 1)
+	for i := 0; i < workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		pool.wg.Add(1)
+		go pool.run(ctx, workerID)
+	}
+
+	log.Printf("Background task workers started: %d", workers)
+	return pool
+}
+
+func (p *WorkerPool) Stop(ctx context.Context) error {
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *WorkerPool) run(ctx context.Context, workerID string) {
+	defer p.wg.Done()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		task, err := p.services.Task.ClaimNext(workerID)
+		if err != nil {
+			log.Printf("ERROR: Worker %s failed to claim task: %v", workerID, err)
+		}
+		if task == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(configs.Conf.Tasks.PollInterval):
+			}
+			continue
+		}
+
+		p.services.Task.Complete(task, p.execute(task))
+	}
+}
+
+func (p *WorkerPool) execute(task *model.Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+	return p.services.Chat.RunTask(task)
+}